@@ -0,0 +1,140 @@
+package iso8601
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+// Reference resolves the calendar parts of durations, so that durations
+// such as P1M and P30D can be compared and aggregated. If Anchor is set,
+// durations are measured exactly by shifting it; otherwise they are
+// measured approximately with Assumptions.
+type Reference struct {
+	// Anchor is the time from which durations are measured exactly.
+	Anchor time.Time
+	// Assumptions are the unit lengths used if Anchor is the zero time.
+	Assumptions Assumptions
+}
+
+// Compare returns -1 if a is shorter than b, +1 if it is longer, and 0 if
+// they are the same length when measured from r. Unlike LessThan, it
+// compares lengths rather than components, so PT90M is longer than PT1H59S
+// and equal to PT1H30M. It can be passed to slices.SortFunc.
+func (r Reference) Compare(a, b Duration) int {
+	if !r.Anchor.IsZero() {
+		return a.Shift(r.Anchor).Compare(b.Shift(r.Anchor))
+	}
+	return cmp.Compare(a.TotalSeconds(r.Assumptions), b.TotalSeconds(r.Assumptions))
+}
+
+// elapsed returns the length of d in nanoseconds, measured from r.
+func (r Reference) elapsed(d Duration) float64 {
+	if !r.Anchor.IsZero() {
+		end := d.Shift(r.Anchor)
+		// Split the difference to avoid saturating time.Duration.
+		return float64(end.Unix()-r.Anchor.Unix())*float64(time.Second) +
+			float64(end.Nanosecond()-r.Anchor.Nanosecond())
+	}
+	return d.TotalSeconds(r.Assumptions) * float64(time.Second)
+}
+
+// ByLength implements sort.Interface for durations, ordering them by
+// Reference.Compare.
+type ByLength struct {
+	Durations []Duration
+	Reference Reference
+}
+
+// Len returns the number of durations.
+func (s ByLength) Len() int {
+	return len(s.Durations)
+}
+
+// Less reports whether the ith duration is shorter than the jth.
+func (s ByLength) Less(i, j int) bool {
+	return s.Reference.Compare(s.Durations[i], s.Durations[j]) < 0
+}
+
+// Swap swaps the ith and jth durations.
+func (s ByLength) Swap(i, j int) {
+	s.Durations[i], s.Durations[j] = s.Durations[j], s.Durations[i]
+}
+
+var errNoDurations = errors.New("no durations")
+
+// Sum returns the component-wise sum of ds, which is exact and needs no
+// Reference, e.g. P1M and P2M sum to P3M.
+func Sum(ds []Duration) Duration {
+	var sum Duration
+	for _, d := range ds {
+		sum = sum.Add(d)
+	}
+	return sum
+}
+
+// Mean returns the average length of ds measured from r. With an anchor,
+// the result is expressed in calendar units from it, as by
+// FromTimeDurationAt; otherwise in days of 24 hours and smaller units, as
+// by FromTimeDurationWith.
+//
+// It returns an error if ds is empty, or ErrOverflow if the mean is not a
+// number, e.g. for seconds of NaN, or does not fit in a time.Duration.
+func Mean(ds []Duration, r Reference) (Duration, error) {
+	if len(ds) == 0 {
+		return Duration{}, errNoDurations
+	}
+	var total float64
+	for _, d := range ds {
+		total += r.elapsed(d)
+	}
+
+	ns := math.Round(total / float64(len(ds)))
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return Duration{}, ErrOverflow
+	}
+	if !r.Anchor.IsZero() {
+		return FromTimeDurationAt(time.Duration(ns), r.Anchor), nil
+	}
+	return FromTimeDurationWith(time.Duration(ns), TimeDurationOptions{LargestUnit: UnitDay}), nil
+}
+
+// Min returns the shortest of ds measured from r, or an error if ds is
+// empty. Of equal durations, the first is returned.
+func Min(ds []Duration, r Reference) (Duration, error) {
+	if len(ds) == 0 {
+		return Duration{}, errNoDurations
+	}
+	return slices.MinFunc(ds, r.Compare), nil
+}
+
+// Max returns the longest of ds measured from r, or an error if ds is
+// empty. Of equal durations, the first is returned.
+func Max(ds []Duration, r Reference) (Duration, error) {
+	if len(ds) == 0 {
+		return Duration{}, errNoDurations
+	}
+	return slices.MaxFunc(ds, r.Compare), nil
+}
+
+// Percentile returns the pth percentile of ds measured from r, for p from
+// 0 to 100, by the nearest-rank method: the shortest of ds that at least p
+// percent of ds are no longer than. The result is always one of ds, and ds
+// is not modified.
+//
+// It returns an error if ds is empty or p is out of range.
+func Percentile(ds []Duration, p float64, r Reference) (Duration, error) {
+	if len(ds) == 0 {
+		return Duration{}, errNoDurations
+	}
+	if !(p >= 0 && p <= 100) {
+		return Duration{}, errors.New("percentile out of range")
+	}
+	sorted := slices.Clone(ds)
+	slices.SortStableFunc(sorted, r.Compare)
+
+	rank := max(int(math.Ceil(p/100*float64(len(sorted)))), 1)
+	return sorted[rank-1], nil
+}
@@ -0,0 +1,138 @@
+package iso8601_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestReferenceCompare(t *testing.T) {
+	feb := iso8601.Reference{Anchor: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	average := iso8601.Reference{}
+	simple := iso8601.Reference{Assumptions: iso8601.SimpleAssumptions}
+
+	cases := []struct {
+		r    iso8601.Reference
+		a, b iso8601.Duration
+		want int
+	}{
+		{average, iso8601.Duration{TM: 90}, iso8601.Duration{TH: 1, TS: 59}, 1},
+		{average, iso8601.Duration{TM: 90}, iso8601.Duration{TH: 1, TM: 30}, 0},
+		{average, iso8601.Duration{M: 1}, iso8601.Duration{D: 30}, 1},
+		{simple, iso8601.Duration{M: 1}, iso8601.Duration{D: 30}, 0},
+		{feb, iso8601.Duration{M: 1}, iso8601.Duration{D: 30}, -1},
+		{feb, iso8601.Duration{M: 1}, iso8601.Duration{D: 29}, 0},
+		{feb, iso8601.Duration{D: -1}, iso8601.Duration{}, -1},
+	}
+	for _, c := range cases {
+		if got := c.r.Compare(c.a, c.b); got != c.want {
+			t.Fatalf("Compare(%s, %s) from %+v: want=%d, got=%d", c.a, c.b, c.r, c.want, got)
+		}
+	}
+}
+
+func TestCanSortDurations(t *testing.T) {
+	r := iso8601.Reference{Anchor: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	ds := []iso8601.Duration{{M: 1}, {TH: 1}, {D: 30}, {W: 1}, {TM: 59}}
+	want := []iso8601.Duration{{TM: 59}, {TH: 1}, {W: 1}, {M: 1}, {D: 30}}
+
+	sorted := slices.Clone(ds)
+	slices.SortFunc(sorted, r.Compare)
+	if !slices.Equal(sorted, want) {
+		t.Fatalf("want=%v, got=%v", want, sorted)
+	}
+
+	sorted = slices.Clone(ds)
+	sort.Sort(iso8601.ByLength{Durations: sorted, Reference: r})
+	if !slices.Equal(sorted, want) {
+		t.Fatalf("want=%v, got=%v", want, sorted)
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	ds := []iso8601.Duration{{TH: 2}, {TM: 30}, {D: 1}, {TH: 1, TM: 30}}
+	r := iso8601.Reference{}
+
+	if got, want := iso8601.Sum(ds), (iso8601.Duration{D: 1, TH: 3, TM: 60}); !got.Equal(want) {
+		t.Fatalf("Sum: want=%s, got=%s", want, got)
+	}
+	if got, want := iso8601.Sum([]iso8601.Duration{{M: 1}, {M: 2}}), (iso8601.Duration{M: 3}); !got.Equal(want) {
+		t.Fatalf("Sum: want=%s, got=%s", want, got)
+	}
+
+	mean, err := iso8601.Mean(ds, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (iso8601.Duration{TH: 7}); !mean.Equal(want) {
+		t.Fatalf("Mean: want=%s, got=%s", want, mean)
+	}
+
+	shortest, err := iso8601.Min(ds, r)
+	if err != nil || !shortest.Equal(iso8601.Duration{TM: 30}) {
+		t.Fatalf("Min: want=PT30M, got=%s %v", shortest, err)
+	}
+	longest, err := iso8601.Max(ds, r)
+	if err != nil || !longest.Equal(iso8601.Duration{D: 1}) {
+		t.Fatalf("Max: want=P1D, got=%s %v", longest, err)
+	}
+
+	percentiles := []struct {
+		p    float64
+		want iso8601.Duration
+	}{
+		{0, iso8601.Duration{TM: 30}},
+		{25, iso8601.Duration{TM: 30}},
+		{50, iso8601.Duration{TH: 1, TM: 30}},
+		{75, iso8601.Duration{TH: 2}},
+		{100, iso8601.Duration{D: 1}},
+	}
+	for _, c := range percentiles {
+		got, err := iso8601.Percentile(ds, c.p, r)
+		if err != nil || !got.Equal(c.want) {
+			t.Fatalf("Percentile(%g): want=%s, got=%s %v", c.p, c.want, got, err)
+		}
+	}
+	if !ds[0].Equal(iso8601.Duration{TH: 2}) {
+		t.Fatal("Percentile should not reorder its input")
+	}
+}
+
+func TestMeanWithAnchor(t *testing.T) {
+	jan := iso8601.Reference{Anchor: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	// P1M and P2M from January 1st 2024 are 31 and 60 days.
+	got, err := iso8601.Mean([]iso8601.Duration{{M: 1}, {M: 2}}, jan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (iso8601.Duration{M: 1, D: 14, TH: 12}); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestAggregatesRejectBadInput(t *testing.T) {
+	r := iso8601.Reference{}
+	if _, err := iso8601.Mean(nil, r); err == nil {
+		t.Fatal("Mean of no durations should fail")
+	}
+	if _, err := iso8601.Min(nil, r); err == nil {
+		t.Fatal("Min of no durations should fail")
+	}
+	if _, err := iso8601.Max(nil, r); err == nil {
+		t.Fatal("Max of no durations should fail")
+	}
+	if _, err := iso8601.Percentile([]iso8601.Duration{{D: 1}}, 101, r); err == nil {
+		t.Fatal("Percentile above 100 should fail")
+	}
+	if _, err := iso8601.Mean([]iso8601.Duration{{Y: 300}}, r); err == nil {
+		t.Fatal("Mean beyond time.Duration should fail")
+	}
+	if _, err := iso8601.Mean([]iso8601.Duration{{TS: math.NaN()}, {TH: 1}}, r); !errors.Is(err, iso8601.ErrOverflow) {
+		t.Fatalf("want=%v, got=%v", iso8601.ErrOverflow, err)
+	}
+}
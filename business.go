@@ -0,0 +1,176 @@
+package iso8601
+
+import (
+	"slices"
+	"time"
+)
+
+// BusinessCalendar defines the working days: every day that is neither a
+// weekend day nor a holiday. The zero value has a Saturday and Sunday
+// weekend and no holidays.
+type BusinessCalendar struct {
+	// Weekend lists the days of the week that are not working days. If nil,
+	// the weekend is Saturday and Sunday; use an empty, non-nil slice for no
+	// weekend.
+	Weekend []time.Weekday
+	// Holidays lists individual dates that are not working days.
+	Holidays []Date
+	// Rules define holidays that recur every year.
+	Rules []HolidayRule
+}
+
+// HolidayRule defines a holiday that recurs every year.
+type HolidayRule interface {
+	// Date returns the date of the holiday in the given year, or false if it
+	// does not occur that year.
+	Date(year int) (Date, bool)
+}
+
+// NthWeekday is the HolidayRule for the nth occurrence of a weekday in a
+// month. Positive N counts from the start of the month and negative N from
+// the end, so the last Monday of May is {time.May, time.Monday, -1}.
+type NthWeekday struct {
+	Month   time.Month
+	Weekday time.Weekday
+	N       int
+}
+
+// Date returns the nth weekday of the month in the given year, or false if
+// the month has fewer than |N| such weekdays, or N is zero.
+func (r NthWeekday) Date(year int) (Date, bool) {
+	last := daysIn(year, r.Month)
+	var day int
+	switch {
+	case r.N > 0:
+		first := time.Date(year, r.Month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		day = 1 + (int(r.Weekday)-int(first)+7)%7 + (r.N-1)*7
+	case r.N < 0:
+		lastWeekday := time.Date(year, r.Month, last, 0, 0, 0, 0, time.UTC).Weekday()
+		day = last - (int(lastWeekday)-int(r.Weekday)+7)%7 + (r.N+1)*7
+	default:
+		return Date{}, false
+	}
+	if day < 1 || day > last {
+		return Date{}, false
+	}
+	return Date{Year: year, Month: r.Month, Day: day}, true
+}
+
+// IsWeekend reports whether d falls on a weekend day.
+func (c BusinessCalendar) IsWeekend(d Date) bool {
+	return c.isWeekend(d.In(time.UTC).Weekday())
+}
+
+func (c BusinessCalendar) isWeekend(wd time.Weekday) bool {
+	if c.Weekend == nil {
+		return wd == time.Saturday || wd == time.Sunday
+	}
+	return slices.Contains(c.Weekend, wd)
+}
+
+// IsHoliday reports whether d is one of the calendar's holidays, either
+// listed or produced by a rule.
+func (c BusinessCalendar) IsHoliday(d Date) bool {
+	if slices.Contains(c.Holidays, d) {
+		return true
+	}
+	// Observed holidays may move into an adjacent year.
+	for year := d.Year - 1; year <= d.Year+1; year++ {
+		for _, rule := range c.Rules {
+			if h, ok := rule.Date(year); ok && h == d {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsBusinessDay reports whether d is a working day.
+func (c BusinessCalendar) IsBusinessDay(d Date) bool {
+	return !c.IsWeekend(d) && !c.IsHoliday(d)
+}
+
+// workdaysPerWeek returns the number of days in a week that are not weekend
+// days.
+func (c BusinessCalendar) workdaysPerWeek() int {
+	n := 0
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if !c.isWeekend(wd) {
+			n++
+		}
+	}
+	return n
+}
+
+// ShiftBusiness returns t shifted by d, with the day part counted in working
+// days of cal: years and months are added as by Shift, then t moves to the
+// W*n+D'th working day after its date, where n is the number of working
+// days in a week, and finally the time part is added as by Shift.
+//
+// Days are counted from the day after t, so P1D from a Friday or Saturday is
+// the following Monday with a Saturday and Sunday weekend. The time of day
+// is kept. If cal has no working days in a week, the day part is ignored.
+func (d Duration) ShiftBusiness(t time.Time, cal BusinessCalendar) time.Time {
+	if d.Y != 0 || d.M != 0 {
+		t = t.AddDate(d.Y, d.M, 0)
+	}
+	t = cal.addBusinessDays(t, d.W*cal.workdaysPerWeek()+d.D)
+	return t.Add(d.timeDuration())
+}
+
+// UnshiftBusiness returns t shifted back by d, with the day part counted in
+// working days of cal, as for ShiftBusiness.
+func (d Duration) UnshiftBusiness(t time.Time, cal BusinessCalendar) time.Time {
+	if d.Y != 0 || d.M != 0 {
+		t = t.AddDate(-d.Y, -d.M, 0)
+	}
+	t = cal.addBusinessDays(t, -(d.W*cal.workdaysPerWeek() + d.D))
+	return t.Add(-d.timeDuration())
+}
+
+// addBusinessDays moves t by n working days, one day at a time, keeping the
+// time of day.
+func (c BusinessCalendar) addBusinessDays(t time.Time, n int) time.Time {
+	if n == 0 || c.workdaysPerWeek() == 0 {
+		return t
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for days := step; ; days += step {
+		next := t.AddDate(0, 0, days)
+		if c.IsBusinessDay(DateOf(next)) {
+			if n--; n == 0 {
+				return next
+			}
+		}
+	}
+}
+
+// BusinessBetween returns the number of working days of cal from one date to
+// another as a day-only Duration, such that shifting from by the result with
+// ShiftBusiness gives to, when to is a working day.
+//
+// Working days after from up to and including to are counted, so the result
+// is P0D for two days of the same weekend. It is negative if to is before
+// from, counting working days from to up to but excluding from.
+func BusinessBetween(from, to Date, cal BusinessCalendar) Duration {
+	sign := to.Compare(from)
+	if sign == 0 {
+		return Duration{}
+	}
+
+	start, end := from, to
+	if sign < 0 {
+		// Count [to, from) as (to-1, from-1].
+		start, end = to.Unshift(Duration{D: 1}), from.Unshift(Duration{D: 1})
+	}
+	n := 0
+	for day := start.Shift(Duration{D: 1}); !day.After(end); day = day.Shift(Duration{D: 1}) {
+		if cal.IsBusinessDay(day) {
+			n++
+		}
+	}
+	return Duration{D: sign * n}
+}
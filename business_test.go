@@ -0,0 +1,142 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestNthWeekday(t *testing.T) {
+	cases := []struct {
+		rule iso8601.NthWeekday
+		want iso8601.Date
+		ok   bool
+	}{
+		{
+			iso8601.NthWeekday{Month: time.May, Weekday: time.Monday, N: -1},
+			iso8601.Date{Year: 2024, Month: time.May, Day: 27}, true,
+		},
+		{
+			iso8601.NthWeekday{Month: time.November, Weekday: time.Thursday, N: 4},
+			iso8601.Date{Year: 2024, Month: time.November, Day: 28}, true,
+		},
+		{
+			iso8601.NthWeekday{Month: time.September, Weekday: time.Monday, N: 1},
+			iso8601.Date{Year: 2024, Month: time.September, Day: 2}, true,
+		},
+		{
+			iso8601.NthWeekday{Month: time.February, Weekday: time.Thursday, N: 5},
+			iso8601.Date{Year: 2024, Month: time.February, Day: 29}, true,
+		},
+		{
+			iso8601.NthWeekday{Month: time.February, Weekday: time.Friday, N: 5},
+			iso8601.Date{}, false,
+		},
+		{
+			iso8601.NthWeekday{Month: time.March, Weekday: time.Sunday, N: -2},
+			iso8601.Date{Year: 2024, Month: time.March, Day: 24}, true,
+		},
+		{
+			iso8601.NthWeekday{Month: time.March, Weekday: time.Sunday, N: 0},
+			iso8601.Date{}, false,
+		},
+	}
+	for _, c := range cases {
+		got, ok := c.rule.Date(2024)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("%+v: want=%s %t, got=%s %t", c.rule, c.want, c.ok, got, ok)
+		}
+	}
+}
+
+func TestBusinessCalendarDays(t *testing.T) {
+	cal := iso8601.BusinessCalendar{
+		Holidays: []iso8601.Date{{Year: 2024, Month: time.December, Day: 25}},
+		Rules:    []iso8601.HolidayRule{iso8601.NthWeekday{Month: time.May, Weekday: time.Monday, N: -1}},
+	}
+	cases := []struct {
+		date     iso8601.Date
+		business bool
+	}{
+		{iso8601.Date{Year: 2024, Month: time.May, Day: 24}, true},  // Friday
+		{iso8601.Date{Year: 2024, Month: time.May, Day: 25}, false}, // Saturday
+		{iso8601.Date{Year: 2024, Month: time.May, Day: 27}, false}, // Memorial Day
+		{iso8601.Date{Year: 2024, Month: time.December, Day: 25}, false},
+		{iso8601.Date{Year: 2025, Month: time.December, Day: 25}, true}, // listed holidays do not recur
+	}
+	for _, c := range cases {
+		if got := cal.IsBusinessDay(c.date); got != c.business {
+			t.Fatalf("%s: want=%t, got=%t", c.date, c.business, got)
+		}
+	}
+
+	fridayWeekend := iso8601.BusinessCalendar{Weekend: []time.Weekday{time.Friday, time.Saturday}}
+	if !fridayWeekend.IsBusinessDay(iso8601.Date{Year: 2024, Month: time.May, Day: 26}) {
+		t.Fatal("Sunday should be a working day with a Friday and Saturday weekend")
+	}
+}
+
+func TestCanShiftByBusinessDays(t *testing.T) {
+	cal := iso8601.BusinessCalendar{
+		Rules: []iso8601.HolidayRule{iso8601.NthWeekday{Month: time.May, Weekday: time.Monday, N: -1}},
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.May, day, hour, 0, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		from time.Time
+		d    iso8601.Duration
+		want time.Time
+	}{
+		{at(21, 10), iso8601.Duration{D: 3}, at(24, 10)},        // Tuesday to Friday
+		{at(23, 10), iso8601.Duration{D: 3}, at(29, 10)},        // over the weekend and Memorial Day
+		{at(25, 10), iso8601.Duration{D: 1}, at(28, 10)},        // from Saturday
+		{at(20, 10), iso8601.Duration{W: 1}, at(28, 10)},        // five working days
+		{at(23, 10), iso8601.Duration{D: 1, TH: 4}, at(24, 14)}, // time part added as is
+		{at(24, 10), iso8601.Duration{D: 0}, at(24, 10)},        // nothing to count
+		{at(29, 10), iso8601.Duration{D: -3}, at(23, 10)},       // negative durations count back
+		{at(15, 10), iso8601.Duration{M: 1, D: 1}, time.Date(2024, time.June, 17, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got := c.d.ShiftBusiness(c.from, cal)
+		if !got.Equal(c.want) {
+			t.Fatalf("%s + %s: want=%s, got=%s", c.from, c.d, c.want, got)
+		}
+		back := c.d.UnshiftBusiness(c.want, cal)
+		if c.d.M == 0 && cal.IsBusinessDay(iso8601.DateOf(c.from)) && !back.Equal(c.from) {
+			t.Fatalf("%s - %s: want=%s, got=%s", c.want, c.d, c.from, back)
+		}
+	}
+}
+
+func TestBusinessBetween(t *testing.T) {
+	cal := iso8601.BusinessCalendar{
+		Rules: []iso8601.HolidayRule{iso8601.NthWeekday{Month: time.May, Weekday: time.Monday, N: -1}},
+	}
+	date := func(day int) iso8601.Date {
+		return iso8601.Date{Year: 2024, Month: time.May, Day: day}
+	}
+	cases := []struct {
+		from, to iso8601.Date
+		want     int
+	}{
+		{date(21), date(24), 3},
+		{date(23), date(29), 3},
+		{date(25), date(26), 0},
+		{date(24), date(24), 0},
+		{date(29), date(23), -3},
+		{date(1), date(31), 21},
+	}
+	for _, c := range cases {
+		got := iso8601.BusinessBetween(c.from, c.to, cal)
+		if got != (iso8601.Duration{D: c.want}) {
+			t.Fatalf("%s to %s: want=%d days, got=%s", c.from, c.to, c.want, got)
+		}
+		from := c.from.In(time.UTC)
+		shifted := got.ShiftBusiness(from, cal)
+		if cal.IsBusinessDay(c.to) && c.want != 0 && iso8601.DateOf(shifted) != c.to {
+			t.Fatalf("%s + %s: want=%s, got=%s", c.from, got, c.to, shifted)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package iso8601
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time and of timer channels, so that
+// time-based code such as Ticker and Timer can be tested deterministically
+// with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a channel that receives the current time once d has
+	// elapsed, like time.NewTimer, and a function that stops the timer and
+	// reports whether it did so before the timer fired.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+// RealClock is the Clock backed by the system clock.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns the channel and Stop method of time.NewTimer(d).
+func (RealClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+// FakeClock is a Clock that only moves when told to, firing pending timers
+// as it passes their deadlines. It is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	changed chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, changed: make(chan struct{})}
+}
+
+// Now returns the current fake time.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer returns a channel that receives the fake time once the clock has
+// been moved at least d past the current fake time, and a function that
+// stops the timer, so that it is no longer pending.
+func (f *FakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c, func() bool { return false }
+	}
+	timer := &fakeTimer{at: f.now.Add(d), c: c}
+	f.timers = append(f.timers, timer)
+	close(f.changed)
+	f.changed = make(chan struct{})
+	return c, func() bool { return f.stop(timer) }
+}
+
+// stop removes timer from the pending timers, and reports whether it was
+// still pending.
+func (f *FakeClock) stop(timer *fakeTimer) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	k := slices.Index(f.timers, timer)
+	if k < 0 {
+		return false
+	}
+	f.timers = slices.Delete(f.timers, k, k+1)
+	return true
+}
+
+// Advance moves the clock forward by d, resolved with Shift, so P1M moves
+// to the same day next month and P1D across a DST change moves 23 or 25
+// hours. Timers due by the new time fire, in deadline order.
+func (f *FakeClock) Advance(d Duration) {
+	f.Set(d.Shift(f.Now()))
+}
+
+// Set moves the clock to t, forwards or backwards, as a clock jump would.
+// Timers due by t fire, in deadline order.
+func (f *FakeClock) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+
+	slices.SortStableFunc(f.timers, func(a, b *fakeTimer) int {
+		return a.at.Compare(b.at)
+	})
+	n := 0
+	for _, timer := range f.timers {
+		if timer.at.After(t) {
+			break
+		}
+		timer.c <- t
+		n++
+	}
+	f.timers = slices.Delete(f.timers, 0, n)
+}
+
+// Pending returns the number of timers that have neither fired nor been
+// stopped.
+func (f *FakeClock) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// BlockUntil blocks until at least n timers are pending, which lets tests
+// wait for a goroutine to start waiting before moving the clock.
+func (f *FakeClock) BlockUntil(n int) {
+	for {
+		f.mu.Lock()
+		pending, changed := len(f.timers), f.changed
+		f.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		<-changed
+	}
+}
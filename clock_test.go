@@ -0,0 +1,94 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+var _ iso8601.Clock = iso8601.RealClock{}
+var _ iso8601.Clock = (*iso8601.FakeClock)(nil)
+
+func TestFakeClockAdvancesByCalendarDuration(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	clock := iso8601.NewFakeClock(time.Date(2024, 3, 30, 12, 0, 0, 0, loc))
+
+	// DST starts on 2024-03-31, so P1D is 23 hours.
+	clock.Advance(iso8601.Duration{D: 1})
+	if got, want := clock.Now(), time.Date(2024, 3, 31, 12, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	// Months roll over as with Shift: April 31st is May 1st.
+	clock.Advance(iso8601.Duration{M: 1, TH: 2})
+	if got, want := clock.Now(), time.Date(2024, 5, 1, 14, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestFakeClockFiresPendingTimers(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+
+	immediate, _ := clock.NewTimer(0)
+	if got := receive(t, immediate); !got.Equal(start) {
+		t.Fatalf("want=%s, got=%s", start, got)
+	}
+
+	hour, _ := clock.NewTimer(time.Hour)
+	day, _ := clock.NewTimer(24 * time.Hour)
+	if got := clock.Pending(); got != 2 {
+		t.Fatalf("want=2 pending, got=%d", got)
+	}
+
+	clock.Advance(iso8601.Duration{TM: 59})
+	expectNoTick(t, hour)
+
+	clock.Advance(iso8601.Duration{TM: 1})
+	if got, want := receive(t, hour), start.Add(time.Hour); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	expectNoTick(t, day)
+	if got := clock.Pending(); got != 1 {
+		t.Fatalf("want=1 pending, got=%d", got)
+	}
+
+	// Moving backwards fires nothing; jumping forward fires what is due.
+	clock.Set(start)
+	expectNoTick(t, day)
+	clock.Advance(iso8601.Duration{W: 1})
+	if got, want := receive(t, day), start.AddDate(0, 0, 7); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestFakeClockStopsTimers(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+
+	hour, stopHour := clock.NewTimer(time.Hour)
+	day, stopDay := clock.NewTimer(24 * time.Hour)
+	if !stopHour() {
+		t.Fatal("Stop of a pending timer should report true")
+	}
+	if stopHour() {
+		t.Fatal("Stop of a stopped timer should report false")
+	}
+	if got := clock.Pending(); got != 1 {
+		t.Fatalf("want=1 pending, got=%d", got)
+	}
+
+	clock.Advance(iso8601.Duration{D: 1})
+	expectNoTick(t, hour)
+	receive(t, day)
+	if stopDay() {
+		t.Fatal("Stop of a fired timer should report false")
+	}
+	if _, stop := clock.NewTimer(0); stop() {
+		t.Fatal("Stop of an immediate timer should report false")
+	}
+}
@@ -0,0 +1,193 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrOverflow is returned when a duration does not fit in a time.Duration.
+var ErrOverflow = errors.New("duration out of range for time.Duration")
+
+// Assumptions are the fixed unit lengths used to convert a Duration to
+// elapsed time without a reference date. Zero fields take the averages of
+// the Gregorian calendar, as in AverageAssumptions.
+type Assumptions struct {
+	DaysPerYear  float64
+	DaysPerMonth float64
+	HoursPerDay  float64
+}
+
+var (
+	// AverageAssumptions are the average lengths over the 400-year
+	// Gregorian cycle: 365.2425-day years, 30.436875-day months and 24-hour
+	// days.
+	AverageAssumptions = Assumptions{DaysPerYear: 365.2425, DaysPerMonth: 30.436875, HoursPerDay: 24}
+	// SimpleAssumptions are 365-day years, 30-day months and 24-hour days.
+	SimpleAssumptions = Assumptions{DaysPerYear: 365, DaysPerMonth: 30, HoursPerDay: 24}
+)
+
+func (a Assumptions) withDefaults() Assumptions {
+	if a.DaysPerYear == 0 {
+		a.DaysPerYear = AverageAssumptions.DaysPerYear
+	}
+	if a.DaysPerMonth == 0 {
+		a.DaysPerMonth = AverageAssumptions.DaysPerMonth
+	}
+	if a.HoursPerDay == 0 {
+		a.HoursPerDay = AverageAssumptions.HoursPerDay
+	}
+	return a
+}
+
+// TotalSeconds returns the length of d in seconds, converting years,
+// months, weeks and days with the given assumptions. Weeks are 7 days.
+func (d Duration) TotalSeconds(a Assumptions) float64 {
+	a = a.withDefaults()
+	days := float64(d.Y)*a.DaysPerYear + float64(d.M)*a.DaysPerMonth + float64(d.W*7+d.D)
+	return days*(a.HoursPerDay*60*60) + float64(d.TH)*60*60 + float64(d.TM)*60 + d.TS
+}
+
+// TotalHours returns the length of d in hours, as for TotalSeconds.
+func (d Duration) TotalHours(a Assumptions) float64 {
+	return d.TotalSeconds(a) / (60 * 60)
+}
+
+// TotalDays returns the length of d in days of a.HoursPerDay hours, as for
+// TotalSeconds.
+func (d Duration) TotalDays(a Assumptions) float64 {
+	return d.TotalSeconds(a) / (a.withDefaults().HoursPerDay * 60 * 60)
+}
+
+// ApproxTimeDuration returns the length of d as a time.Duration, as for
+// TotalSeconds, rounded to the nearest nanosecond. Unlike ToTimeDuration,
+// no component is dropped.
+//
+// It returns ErrOverflow if the result does not fit in a time.Duration,
+// i.e. for more than about 292 years.
+func (d Duration) ApproxTimeDuration(a Assumptions) (time.Duration, error) {
+	ns := math.Round(d.TotalSeconds(a) * float64(time.Second))
+	// float64(math.MaxInt64) rounds up to 2^63, which is already out of range.
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return time.Duration(ns), nil
+}
+
+// ToTimeDurationFrom returns the elapsed time from anchor to d.Shift(anchor),
+// which is exact for calendar units, e.g. P1M from February 1st 2024 is
+// 696h and P1D across a DST change is 23h or 25h.
+//
+// It returns ErrOverflow if the time part of d or the result does not fit
+// in a time.Duration.
+func (d Duration) ToTimeDurationFrom(anchor time.Time) (time.Duration, error) {
+	timePart := Duration{TH: d.TH, TM: d.TM, TS: d.TS}
+	if _, err := timePart.ApproxTimeDuration(AverageAssumptions); err != nil {
+		return 0, err
+	}
+
+	end := d.Shift(anchor)
+	elapsed := end.Sub(anchor)
+	// Sub saturates at the limits of time.Duration rather than failing.
+	if !anchor.Add(elapsed).Equal(end) {
+		return 0, ErrOverflow
+	}
+	return elapsed, nil
+}
+
+// FromTimeDurationAt expresses td elapsed from anchor in calendar units, as
+// the inverse of ToTimeDurationFrom: years, months and days as counted by
+// BetweenDates on the wall clock in anchor's location, then the remaining
+// hours, minutes and seconds, such that the result shifted from anchor is
+// anchor.Add(td).
+//
+// For example, 744h from January 1st is P1M, but from February 1st 2024 it
+// is P1M2D. All components have the sign of td.
+func FromTimeDurationAt(td time.Duration, anchor time.Time) Duration {
+	end := anchor.Add(td)
+	from, to := DateOf(anchor), DateOf(end)
+
+	sign := 1
+	if td < 0 {
+		sign = -1
+	}
+	d := BetweenDates(from, to)
+	// Whole days may overshoot when the time of day at end has not yet been
+	// reached, in the direction of td.
+	if end.Sub(d.Shift(anchor))*time.Duration(sign) < 0 {
+		d = BetweenDates(from, to.Unshift(Duration{D: sign}))
+	}
+
+	rest := FromTimeDuration(end.Sub(d.Shift(anchor)))
+	d.TH, d.TM, d.TS = rest.TH, rest.TM, rest.TS
+	return d
+}
+
+// Unit is a component of a Duration, from seconds to years.
+type Unit int
+
+const (
+	// UnitSecond is the TS component.
+	UnitSecond Unit = iota + 1
+	// UnitMinute is the TM component.
+	UnitMinute
+	// UnitHour is the TH component.
+	UnitHour
+	// UnitDay is the D component.
+	UnitDay
+	// UnitWeek is the W component.
+	UnitWeek
+	// UnitMonth is the M component.
+	UnitMonth
+	// UnitYear is the Y component.
+	UnitYear
+)
+
+// TimeDurationOptions controls how FromTimeDurationWith splits a
+// time.Duration into components. The zero value gives the same result as
+// FromTimeDuration.
+type TimeDurationOptions struct {
+	// LargestUnit is the largest component filled, from UnitSecond to
+	// UnitWeek, with 24-hour days. Months and years have no fixed length,
+	// so UnitMonth and UnitYear fill weeks at most. If zero, it is
+	// UnitHour.
+	LargestUnit Unit
+	// RoundSeconds rounds to the nearest whole second, halfway values away
+	// from zero, instead of keeping the sub-second part as fractional
+	// seconds.
+	RoundSeconds bool
+}
+
+// FromTimeDurationWith creates a Duration from a time.Duration, split into
+// components according to opts, e.g. 50h is P2DT2H with a LargestUnit of
+// UnitDay. All components have the sign of td.
+func FromTimeDurationWith(td time.Duration, opts TimeDurationOptions) Duration {
+	if opts.RoundSeconds {
+		td = td.Round(time.Second)
+	}
+	largest := opts.LargestUnit
+	if largest == 0 {
+		largest = UnitHour
+	}
+
+	var d Duration
+	split := func(unit time.Duration) int {
+		n := td / unit
+		td -= n * unit
+		return int(n)
+	}
+	if largest >= UnitWeek {
+		d.W = split(7 * oneDay)
+	}
+	if largest >= UnitDay {
+		d.D = split(oneDay)
+	}
+	if largest >= UnitHour {
+		d.TH = split(time.Hour)
+	}
+	if largest >= UnitMinute {
+		d.TM = split(time.Minute)
+	}
+	d.TS = float64(td) / float64(time.Second)
+	return d
+}
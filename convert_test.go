@@ -0,0 +1,174 @@
+package iso8601_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestTotalsUseAssumptions(t *testing.T) {
+	cases := []struct {
+		d       iso8601.Duration
+		a       iso8601.Assumptions
+		seconds float64
+	}{
+		{iso8601.Duration{TH: 1, TM: 30, TS: 1.5}, iso8601.AverageAssumptions, 5401.5},
+		{iso8601.Duration{D: 1}, iso8601.AverageAssumptions, 86400},
+		{iso8601.Duration{W: 1}, iso8601.SimpleAssumptions, 7 * 86400},
+		{iso8601.Duration{M: 1}, iso8601.SimpleAssumptions, 30 * 86400},
+		{iso8601.Duration{M: 1}, iso8601.AverageAssumptions, 30.436875 * 86400},
+		{iso8601.Duration{Y: 1}, iso8601.SimpleAssumptions, 365 * 86400},
+		{iso8601.Duration{Y: 1}, iso8601.Assumptions{}, 365.2425 * 86400},
+		{iso8601.Duration{D: 1}, iso8601.Assumptions{HoursPerDay: 8}, 8 * 3600},
+		{iso8601.Duration{D: -1, TH: 2}, iso8601.SimpleAssumptions, -22 * 3600},
+	}
+	for _, c := range cases {
+		if got := c.d.TotalSeconds(c.a); math.Abs(got-c.seconds) > 1e-6 {
+			t.Fatalf("%s: want=%f seconds, got=%f", c.d, c.seconds, got)
+		}
+		if got := c.d.TotalHours(c.a); math.Abs(got-c.seconds/3600) > 1e-9 {
+			t.Fatalf("%s: want=%f hours, got=%f", c.d, c.seconds/3600, got)
+		}
+	}
+
+	if got := (iso8601.Duration{D: 1, TH: 12}).TotalDays(iso8601.AverageAssumptions); got != 1.5 {
+		t.Fatalf("want=1.5 days, got=%f", got)
+	}
+	if got := (iso8601.Duration{D: 1, TH: 4}).TotalDays(iso8601.Assumptions{HoursPerDay: 8}); got != 1.5 {
+		t.Fatalf("want=1.5 working days, got=%f", got)
+	}
+}
+
+func TestApproxTimeDuration(t *testing.T) {
+	got, err := iso8601.Duration{M: 1, D: 1, TS: 0.5}.ApproxTimeDuration(iso8601.SimpleAssumptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 31*24*time.Hour + 500*time.Millisecond; got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	got, err = iso8601.Duration{Y: -1}.ApproxTimeDuration(iso8601.AverageAssumptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := -31556952 * time.Second; got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	for _, d := range []iso8601.Duration{{Y: 300}, {Y: -300}, {TS: math.NaN()}} {
+		if _, err := d.ApproxTimeDuration(iso8601.AverageAssumptions); !errors.Is(err, iso8601.ErrOverflow) {
+			t.Fatalf("%s: want ErrOverflow, got %v", d, err)
+		}
+	}
+}
+
+func TestToTimeDurationFrom(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	cases := []struct {
+		d      iso8601.Duration
+		anchor time.Time
+		want   time.Duration
+	}{
+		{iso8601.Duration{M: 1}, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), 696 * time.Hour},
+		{iso8601.Duration{M: 1}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 744 * time.Hour},
+		{iso8601.Duration{D: 1}, time.Date(2024, 3, 9, 12, 0, 0, 0, loc), 23 * time.Hour},
+		{iso8601.Duration{D: -1}, time.Date(2024, 11, 3, 18, 0, 0, 0, loc), -25 * time.Hour},
+		{iso8601.Duration{TH: 1, TS: 0.5}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Hour + 500*time.Millisecond},
+	}
+	for _, c := range cases {
+		got, err := c.d.ToTimeDurationFrom(c.anchor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("%s from %s: want=%s, got=%s", c.d, c.anchor, c.want, got)
+		}
+	}
+
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	overflows := []iso8601.Duration{{Y: 300}, {D: -300 * 366}, {TH: math.MaxInt64 / 2}, {Y: 200, TH: 200 * 366 * 24}}
+	for _, d := range overflows {
+		if _, err := d.ToTimeDurationFrom(anchor); !errors.Is(err, iso8601.ErrOverflow) {
+			t.Fatalf("%s: want ErrOverflow, got %v", d, err)
+		}
+	}
+}
+
+func TestFromTimeDurationAt(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	cases := []struct {
+		td     time.Duration
+		anchor time.Time
+		want   iso8601.Duration
+	}{
+		{744 * time.Hour, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), iso8601.Duration{M: 1}},
+		{744 * time.Hour, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), iso8601.Duration{M: 1, D: 2}},
+		{23 * time.Hour, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), iso8601.Duration{TH: 23}},
+		{26*time.Hour + 30*time.Minute, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), iso8601.Duration{D: 1, TH: 2, TM: 30}},
+		{23 * time.Hour, time.Date(2024, 3, 9, 12, 0, 0, 0, loc), iso8601.Duration{D: 1}},
+		{-25 * time.Hour, time.Date(2024, 11, 3, 18, 0, 0, 0, loc), iso8601.Duration{D: -1}},
+		{-26 * time.Hour, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), iso8601.Duration{D: -1, TH: -2}},
+		{0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), iso8601.Duration{}},
+		{16350295721 * time.Nanosecond, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), iso8601.Duration{TS: 16.350295721}},
+	}
+	for _, c := range cases {
+		got := iso8601.FromTimeDurationAt(c.td, c.anchor)
+		if !got.Equal(c.want) {
+			t.Fatalf("%s from %s: want=%s, got=%s", c.td, c.anchor, c.want, got)
+		}
+		if back, err := got.ToTimeDurationFrom(c.anchor); err != nil || back != c.td {
+			t.Fatalf("%s from %s: want=%s, got=%s %v", got, c.anchor, c.td, back, err)
+		}
+	}
+}
+
+func TestFromTimeDurationAtIsExactToTheNanosecond(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 10000 {
+		td := time.Duration(r.Int64N(int64(400 * 24 * time.Hour)))
+		got := iso8601.FromTimeDurationAt(td, anchor)
+		if shifted := got.Shift(anchor); !shifted.Equal(anchor.Add(td)) {
+			t.Fatalf("%s from %s: want=%s, got=%s via %s", td, anchor, anchor.Add(td), shifted, got)
+		}
+	}
+}
+
+func TestFromTimeDurationWith(t *testing.T) {
+	cases := []struct {
+		td   time.Duration
+		opts iso8601.TimeDurationOptions
+		want iso8601.Duration
+	}{
+		{50 * time.Hour, iso8601.TimeDurationOptions{}, iso8601.Duration{TH: 50}},
+		{50 * time.Hour, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitDay}, iso8601.Duration{D: 2, TH: 2}},
+		{200 * time.Hour, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitWeek}, iso8601.Duration{W: 1, D: 1, TH: 8}},
+		{200 * time.Hour, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitYear}, iso8601.Duration{W: 1, D: 1, TH: 8}},
+		{90 * time.Minute, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitMinute}, iso8601.Duration{TM: 90}},
+		{90 * time.Minute, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitSecond}, iso8601.Duration{TS: 5400}},
+		{-50 * time.Hour, iso8601.TimeDurationOptions{LargestUnit: iso8601.UnitDay}, iso8601.Duration{D: -2, TH: -2}},
+		{time.Minute + 1500*time.Millisecond, iso8601.TimeDurationOptions{}, iso8601.Duration{TM: 1, TS: 1.5}},
+		{
+			time.Minute + 1500*time.Millisecond, iso8601.TimeDurationOptions{RoundSeconds: true},
+			iso8601.Duration{TM: 1, TS: 2},
+		},
+		{-1500 * time.Millisecond, iso8601.TimeDurationOptions{RoundSeconds: true}, iso8601.Duration{TS: -2}},
+		{time.Hour - 200*time.Millisecond, iso8601.TimeDurationOptions{RoundSeconds: true}, iso8601.Duration{TH: 1}},
+	}
+	for _, c := range cases {
+		if got := iso8601.FromTimeDurationWith(c.td, c.opts); !got.Equal(c.want) {
+			t.Fatalf("%s with %+v: want=%s, got=%s", c.td, c.opts, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package iso8601
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var datePattern = regexp.MustCompile(
+	`^` + yearExpr + `(?:` + extendedDateExpr + `|` + basicDateExpr + `)$`)
+
+// Date represents a calendar date, without a time of day or a location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date on which t falls, in t's location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a complete ISO8601 date: a calendar date (2024-03-01 or
+// 20240301), a week date (2024-W09-5) or an ordinal date (2024-061). Years
+// outside 0000-9999 are accepted in the expanded form written by String,
+// e.g. +012345-01-02.
+func ParseDate(from string) (Date, error) {
+	parts := submatches(datePattern, from)
+	if parts == nil {
+		return Date{}, errors.New("could not parse date string")
+	}
+
+	year, month, day, complete, err := resolveDate(parts)
+	if err != nil {
+		return Date{}, err
+	}
+	if !complete {
+		return Date{}, errors.New("could not parse date string")
+	}
+	return Date{Year: year, Month: time.Month(month), Day: day}, nil
+}
+
+// In returns midnight at the start of d in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsValid reports whether d is a valid date, e.g. not February 30th.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// Compare returns -1 if d is before other, +1 if it is after, and 0 if
+// they are the same date.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return cmp.Compare(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmp.Compare(d.Month, other.Month)
+	default:
+		return cmp.Compare(d.Day, other.Day)
+	}
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Shift returns the date shifted by the date part of dur.
+//
+// NB: Shift has the same month overflow semantics as Duration.Shift, e.g.
+// 2024-08-31 + P1M = 2024-10-01. The time part of dur is ignored.
+//
+// Week and Day values will be combined as W*7 + D.
+func (d Date) Shift(dur Duration) Date {
+	return DateOf(d.In(time.UTC).AddDate(dur.Y, dur.M, dur.W*7+dur.D))
+}
+
+// Unshift returns the date shifted back by the date part of dur.
+//
+// NB: Unshift has the same month overflow semantics as Duration.Unshift,
+// e.g. 2024-03-31 - P1M = 2024-03-02. The time part of dur is ignored.
+func (d Date) Unshift(dur Duration) Date {
+	return DateOf(d.In(time.UTC).AddDate(-dur.Y, -dur.M, -(dur.W*7 + dur.D)))
+}
+
+// BetweenDates returns the date-only Duration from one date to another, in
+// years, months and days, such that from.Shift(BetweenDates(from, to)) == to.
+//
+// The result is negative if to is before from. Months are counted with the
+// overflow semantics of Shift, so BetweenDates(2024-01-31, 2024-03-01) is P30D
+// rather than P1M1D, because 2024-01-31 + P1M is already 2024-03-02.
+func BetweenDates(from, to Date) Duration {
+	sign := to.Compare(from)
+	if sign == 0 {
+		return Duration{}
+	}
+
+	start := from.In(time.UTC)
+	end := to.In(time.UTC)
+	months := (to.Year-from.Year)*12 + int(to.Month-from.Month)
+	// Step back until shifting by whole months does not pass the end date.
+	for months != 0 && start.AddDate(0, months, 0).Compare(end) == sign {
+		months -= sign
+	}
+	days := int(end.Sub(start.AddDate(0, months, 0)) / oneDay)
+
+	return Duration{Y: months / 12, M: months % 12, D: days}
+}
+
+// String returns the extended representation of d, e.g. 2024-03-01, or
+// -000005-01-02 for a year outside 0000-9999.
+func (d Date) String() string {
+	var buf [16]byte
+	return string(d.AppendFormat(buf[:0]))
+}
+
+// AppendFormat appends the extended representation of d, as returned by
+// String, to dst and returns the extended buffer.
+func (d Date) AppendFormat(dst []byte) []byte {
+	dst = appendYear(dst, d.Year)
+	dst = append(dst, '-')
+	dst = appendDigits(dst, int(d.Month), 2)
+	dst = append(dst, '-')
+	return appendDigits(dst, d.Day, 2)
+}
+
+// MarshalJSON satisfies json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	tmp, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = tmp
+
+	return nil
+}
+
+// Value satisfies driver.Valuer. The date is stored in its extended
+// representation, which SQL DATE columns accept.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan satisfies sql.Scanner. It accepts time.Time values, as returned by
+// most drivers for DATE columns, and strings in any form ParseDate accepts.
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+}
+
+func (d *Date) scanString(s string) error {
+	// Some drivers return DATE columns as a full timestamp.
+	if len(s) > 10 {
+		if t, err := ParseDateTime(s); err == nil {
+			*d = DateOf(t)
+			return nil
+		}
+	}
+
+	tmp, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = tmp
+
+	return nil
+}
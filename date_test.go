@@ -0,0 +1,206 @@
+package iso8601_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanParseDate(t *testing.T) {
+	want := iso8601.Date{Year: 2024, Month: time.March, Day: 1}
+	for _, from := range []string{"2024-03-01", "20240301", "2024-W09-5", "2024061"} {
+		got, err := iso8601.ParseDate(from)
+		if err != nil {
+			t.Fatalf("%s: failed to parse: %v", from, err)
+		}
+		if got != want {
+			t.Fatalf("%s: want=%s, got=%s", from, want, got)
+		}
+	}
+
+	for _, bad := range []string{"", "2024", "2024-03", "2024-W09", "2024-02-30", "2024-03-01T00:00", "+12345-01-02"} {
+		if _, err := iso8601.ParseDate(bad); err == nil {
+			t.Fatalf("%s: Expected error, got none", bad)
+		}
+	}
+}
+
+func TestCanStringifyDate(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Date
+		want string
+	}{
+		{iso8601.Date{Year: 2024, Month: time.March, Day: 1}, "2024-03-01"},
+		{iso8601.Date{Year: 12345, Month: time.January, Day: 2}, "+012345-01-02"},
+		{iso8601.Date{Year: -5, Month: time.January, Day: 2}, "-000005-01-02"},
+	}
+	for _, c := range cases {
+		if got := c.d.String(); got != c.want {
+			t.Fatalf("want=%s, got=%s", c.want, got)
+		}
+		if got, err := iso8601.ParseDate(c.want); err != nil || got != c.d {
+			t.Fatalf("ParseDate(%s): want=%s, got=%s (%v)", c.want, c.d, got, err)
+		}
+	}
+}
+
+func TestCanShiftDate(t *testing.T) {
+	cases := []struct {
+		from     iso8601.Date
+		duration iso8601.Duration
+		want     iso8601.Date
+	}{
+		{
+			iso8601.Date{Year: 2024, Month: 1, Day: 15}, iso8601.Duration{M: 1},
+			iso8601.Date{Year: 2024, Month: 2, Day: 15},
+		},
+		{
+			iso8601.Date{Year: 2024, Month: 8, Day: 31}, iso8601.Duration{M: 1},
+			iso8601.Date{Year: 2024, Month: 10, Day: 1},
+		},
+		{
+			iso8601.Date{Year: 2024, Month: 2, Day: 29}, iso8601.Duration{Y: 1},
+			iso8601.Date{Year: 2025, Month: 3, Day: 1},
+		},
+		{
+			iso8601.Date{Year: 2024, Month: 12, Day: 25}, iso8601.Duration{W: 1, D: 1},
+			iso8601.Date{Year: 2025, Month: 1, Day: 2},
+		},
+		{
+			iso8601.Date{Year: 2024, Month: 3, Day: 1}, iso8601.Duration{D: 1, TH: 23},
+			iso8601.Date{Year: 2024, Month: 3, Day: 2},
+		},
+	}
+
+	for k, c := range cases {
+		if got := c.from.Shift(c.duration); got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		want := c.from.In(time.UTC).AddDate(c.duration.Y, c.duration.M, c.duration.W*7+c.duration.D)
+		if got := c.from.Shift(c.duration).In(time.UTC); !got.Equal(want) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, want, got)
+		}
+	}
+
+	from := iso8601.Date{Year: 2024, Month: 3, Day: 31}
+	if got, want := from.Unshift(iso8601.Duration{M: 1}), (iso8601.Date{Year: 2024, Month: 3, Day: 2}); got != want {
+		t.Fatalf("Unshift: want=%s, got=%s", want, got)
+	}
+}
+
+func TestCanGetDurationBetweenDates(t *testing.T) {
+	cases := []struct {
+		from string
+		to   string
+		want iso8601.Duration
+	}{
+		{"2024-03-01", "2024-03-01", iso8601.Duration{}},
+		{"2024-03-01", "2024-03-15", iso8601.Duration{D: 14}},
+		{"2024-01-15", "2024-04-10", iso8601.Duration{M: 2, D: 26}},
+		{"2000-02-29", "2024-02-28", iso8601.Duration{Y: 23, M: 11, D: 30}},
+		{"2024-01-31", "2024-03-01", iso8601.Duration{D: 30}},
+		{"2024-04-10", "2024-01-15", iso8601.Duration{M: -2, D: -26}},
+		{"2024-03-31", "2024-02-28", iso8601.Duration{M: -1, D: -3}},
+		{"2023-12-31", "2026-01-01", iso8601.Duration{Y: 2, D: 1}},
+	}
+
+	for k, c := range cases {
+		from, err := iso8601.ParseDate(c.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		to, err := iso8601.ParseDate(c.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := iso8601.BetweenDates(from, to)
+		if got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		if shifted := from.Shift(got); shifted != to {
+			t.Fatalf("Case %d: %s + %s: want=%s, got=%s", k, from, got, to, shifted)
+		}
+	}
+}
+
+func TestCanCompareDates(t *testing.T) {
+	a := iso8601.Date{Year: 2024, Month: 3, Day: 1}
+	b := iso8601.Date{Year: 2024, Month: 3, Day: 2}
+	if !a.Before(b) || a.After(b) || a.Compare(a) != 0 || b.Compare(a) != 1 {
+		t.Fatalf("unexpected ordering of %s and %s", a, b)
+	}
+	if (iso8601.Date{Year: 2024, Month: 2, Day: 30}).IsValid() || !a.IsValid() {
+		t.Fatal("unexpected validity")
+	}
+}
+
+func TestCanMarshalDateJSON(t *testing.T) {
+	want := iso8601.Date{Year: 2024, Month: 3, Day: 1}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"2024-03-01"` {
+		t.Fatalf("want=%s, got=%s", `"2024-03-01"`, b)
+	}
+
+	var got iso8601.Date
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	if err := json.Unmarshal([]byte(`"2024-02-30"`), &got); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestCanScanDate(t *testing.T) {
+	want := iso8601.Date{Year: 2024, Month: 3, Day: 1}
+	v, err := want.Value()
+	if err != nil || v != "2024-03-01" {
+		t.Fatalf("Value: want=2024-03-01, got=%v (%v)", v, err)
+	}
+
+	cases := []any{
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2024-03-01",
+		[]byte("2024-03-01"),
+		"2024-03-01T00:00:00Z",
+	}
+	for k, c := range cases {
+		var got iso8601.Date
+		if err := got.Scan(c); err != nil {
+			t.Fatalf("Case %d: %v", k, err)
+		}
+		if got != want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, want, got)
+		}
+	}
+
+	far := iso8601.Date{Year: 12345, Month: 1, Day: 2}
+	b, err := json.Marshal(far)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotFar iso8601.Date
+	if err := json.Unmarshal(b, &gotFar); err != nil || gotFar != far {
+		t.Fatalf("JSON: want=%s, got=%s (%v)", far, gotFar, err)
+	}
+	if v, err = far.Value(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gotFar.Scan(v); err != nil || gotFar != far {
+		t.Fatalf("Scan: want=%s, got=%s (%v)", far, gotFar, err)
+	}
+
+	var got iso8601.Date
+	if err := got.Scan(42); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
@@ -0,0 +1,468 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date and time patterns. Groups for the basic format are prefixed with "b"
+// and folded into their extended counterparts by submatches.
+const (
+	yearExpr = `(?P<year>\d{4}|[+-]\d{6})`
+
+	extendedCalendarExpr = `-(?P<month>\d{2})(?:-(?P<day>\d{2}))?`
+	extendedWeekExpr     = `-W(?P<week>\d{2})(?:-(?P<weekday>\d))?`
+	extendedOrdinalExpr  = `-(?P<yday>\d{3})`
+	extendedDateExpr     = `(?:` + extendedCalendarExpr + `|` + extendedWeekExpr + `|` + extendedOrdinalExpr + `)`
+	extendedTimeExpr     = `(?P<hour>\d{2})(?::(?P<minute>\d{2})(?::(?P<second>\d{2}))?)?(?P<fraction>[.,]\d+)?`
+	extendedOffsetExpr   = `(?P<offset>Z|[+-]\d{2}(?::\d{2})?)`
+
+	basicCalendarExpr = `(?P<bmonth>\d{2})(?P<bday>\d{2})`
+	basicWeekExpr     = `W(?P<bweek>\d{2})(?P<bweekday>\d)?`
+	basicOrdinalExpr  = `(?P<byday>\d{3})`
+	basicDateExpr     = `(?:` + basicCalendarExpr + `|` + basicWeekExpr + `|` + basicOrdinalExpr + `)`
+	basicTimeExpr     = `(?P<bhour>\d{2})(?:(?P<bminute>\d{2})(?P<bsecond>\d{2})?)?(?P<bfraction>[.,]\d+)?`
+	basicOffsetExpr   = `(?P<boffset>Z|[+-]\d{2}(?:\d{2})?)`
+
+	weekDateExpr    = `(?:` + extendedWeekExpr + `|` + basicWeekExpr + `)`
+	ordinalDateExpr = `(?:` + extendedOrdinalExpr + `|` + basicOrdinalExpr + `)`
+	timeExpr        = `(?:` + extendedTimeExpr + `|` + basicTimeExpr + `)`
+)
+
+// dateTimePattern matches a date-time written entirely in either the
+// extended or the basic format, as ISO8601 does not allow them to be mixed.
+var dateTimePattern = regexp.MustCompile(`^` + yearExpr + `(?:` +
+	extendedDateExpr + `(?:T` + extendedTimeExpr + extendedOffsetExpr + `?)?|` +
+	basicDateExpr + `(?:T` + basicTimeExpr + basicOffsetExpr + `?)?)?$`)
+
+// submatches returns the non-empty named groups of re in s, or nil if s
+// does not match.
+func submatches(re *regexp.Regexp, s string) map[string]string {
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return nil
+	}
+
+	parts := make(map[string]string, len(match))
+	for i, name := range re.SubexpNames() {
+		if i != 0 && name != "" && match[i] != "" {
+			parts[strings.TrimPrefix(name, "b")] = match[i]
+		}
+	}
+	return parts
+}
+
+// ParseDateTime parses an ISO8601 date or date-time string.
+//
+// Both the extended (2024-03-01T12:00:00Z) and basic (20240301T120000Z)
+// formats are accepted, as are reduced precision forms (2024, 2024-03,
+// 2024-03-01T12, 2024-03-01T12:30), a decimal fraction on the least
+// significant time component (12.5 is 12:30, with either '.' or ',' as the
+// decimal sign) and 24:00 as the end of the day, i.e. midnight of the next
+// day. The offset may be written as Z, ±hh, or ±hh:mm in the extended format
+// and ±hhmm in the basic format. The date, time and offset must all be
+// written in the same format.
+//
+// Years outside 0000-9999 are accepted in the expanded form, with a sign and
+// six digits, e.g. +012345-01-02 or -000005-01-02.
+//
+// The date may also be a week date (2024-W09-5, or 2024-W09 for its
+// Monday) or an ordinal date (2024-061).
+//
+// A date-time without an offset is interpreted as UTC; use
+// ParseDateTimeInLocation to interpret it in another location.
+func ParseDateTime(from string) (time.Time, error) {
+	return ParseDateTimeInLocation(from, time.UTC)
+}
+
+// ParseDateTimeInLocation is like ParseDateTime, but interprets a date-time
+// without an offset in the given location.
+func ParseDateTimeInLocation(from string, loc *time.Location) (time.Time, error) {
+	parts := submatches(dateTimePattern, from)
+	if parts == nil {
+		return time.Time{}, errors.New("could not parse date-time string")
+	}
+
+	year, month, day, complete, err := resolveDate(parts)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	_, hasTime := parts["hour"]
+	if hasTime && !complete {
+		return time.Time{}, errors.New("time requires a complete date")
+	}
+
+	var clock time.Duration
+	if hasTime {
+		clock, err = resolveClock(parts)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if s, ok := parts["offset"]; ok {
+		offset, err := parseOffset(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = offset
+	}
+
+	// Split the time of day into wall clock fields rather than adding it to
+	// midnight, so that it is not affected by DST changes.
+	days := int(clock / oneDay)
+	clock %= oneDay
+
+	return time.Date(year, time.Month(month), day+days,
+		int(clock/time.Hour), int(clock%time.Hour/time.Minute), int(clock%time.Minute/time.Second),
+		int(clock%time.Second), loc), nil
+}
+
+// resolveClock returns the time elapsed since midnight for the time groups
+// in parts. 24:00 is the end of the day, i.e. 24 hours.
+func resolveClock(parts map[string]string) (time.Duration, error) {
+	var minute, second int
+	hour, _ := strconv.Atoi(parts["hour"]) //nolint:errcheck // Guaranteed digits by pattern
+	unit := time.Hour
+	if s, ok := parts["minute"]; ok {
+		minute, _ = strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+		unit = time.Minute
+	}
+	if s, ok := parts["second"]; ok {
+		second, _ = strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+		unit = time.Second
+	}
+	if hour > 24 || minute > 59 || second > 59 {
+		return 0, errors.New("time out of range")
+	}
+
+	// The fraction applies to the least significant time component.
+	var fraction time.Duration
+	if s, ok := parts["fraction"]; ok {
+		fraction = parseFraction(s[1:], unit)
+	}
+
+	if hour == 24 && (minute != 0 || second != 0 || fraction != 0) {
+		return 0, errors.New("time out of range")
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + fraction, nil
+}
+
+// resolveDate returns the calendar date for the date groups in parts, and
+// whether it was written to the day rather than with reduced precision.
+func resolveDate(parts map[string]string) (year, month, day int, complete bool, err error) {
+	year, _ = strconv.Atoi(parts["year"]) //nolint:errcheck // Guaranteed digits by pattern
+	if _, ok := parts["week"]; ok {
+		return resolveWeekDate(year, parts)
+	}
+	if s, ok := parts["yday"]; ok {
+		return resolveOrdinalDate(year, s)
+	}
+
+	month, day = 1, 1
+	if s, ok := parts["month"]; ok {
+		month, _ = strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+		if month < 1 || month > 12 {
+			return 0, 0, 0, false, errors.New("month out of range")
+		}
+	}
+	if s, ok := parts["day"]; ok {
+		day, _ = strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+		if day < 1 || day > daysIn(year, time.Month(month)) {
+			return 0, 0, 0, false, errors.New("day out of range")
+		}
+		complete = true
+	}
+	return year, month, day, complete, nil
+}
+
+// resolveWeekDate returns the calendar date for the week date groups in
+// parts, as for resolveDate. A week without a weekday is its Monday.
+func resolveWeekDate(year int, parts map[string]string) (y, month, day int, complete bool, err error) {
+	w := WeekDate{Year: year, Weekday: time.Monday}
+	w.Week, _ = strconv.Atoi(parts["week"]) //nolint:errcheck // Guaranteed digits by pattern
+	s, complete := parts["weekday"]
+	if complete {
+		n, _ := strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+		if n < 1 || n > 7 {
+			return 0, 0, 0, false, errors.New("weekday out of range")
+		}
+		w.Weekday = time.Weekday(n % 7)
+	}
+	if w.Week < 1 || w.Week > weeksIn(year) {
+		return 0, 0, 0, false, errors.New("week out of range")
+	}
+	y, m, day := w.Time(time.UTC).Date()
+	return y, int(m), day, complete, nil
+}
+
+// resolveOrdinalDate returns the calendar date for the day of year s, as
+// for resolveDate.
+func resolveOrdinalDate(year int, s string) (y, month, day int, complete bool, err error) {
+	yday, _ := strconv.Atoi(s) //nolint:errcheck // Guaranteed digits by pattern
+	if yday < 1 || yday > daysInYear(year) {
+		return 0, 0, 0, false, errors.New("day of year out of range")
+	}
+	y, m, day := OrdinalDate{Year: year, Day: yday}.Time(time.UTC).Date()
+	return y, int(m), day, true, nil
+}
+
+// parseFraction returns the digits of a decimal fraction of unit as a
+// duration. Seconds are truncated to nanoseconds, like time.Parse; larger
+// units are rounded to the nearest nanosecond.
+func parseFraction(digits string, unit time.Duration) time.Duration {
+	if unit == time.Second {
+		if len(digits) > 9 {
+			digits = digits[:9]
+		}
+		ns, _ := strconv.Atoi(digits + strings.Repeat("0", 9-len(digits))) //nolint:errcheck // Guaranteed digits
+		return time.Duration(ns)
+	}
+
+	f, _ := strconv.ParseFloat("0."+digits, 64) //nolint:errcheck // Guaranteed digits by pattern
+	return time.Duration(math.Round(f * float64(unit)))
+}
+
+// parseOffset parses a UTC offset in one of the forms Z, ±hh, ±hhmm or
+// ±hh:mm.
+func parseOffset(s string) (*time.Location, error) {
+	if s == "Z" {
+		return time.UTC, nil
+	}
+
+	digits := strings.ReplaceAll(s[1:], ":", "")
+	hours, _ := strconv.Atoi(digits[:2]) //nolint:errcheck // Guaranteed digits by pattern
+	var minutes int
+	if len(digits) == 4 {
+		minutes, _ = strconv.Atoi(digits[2:]) //nolint:errcheck // Guaranteed digits by pattern
+	}
+	if hours > 23 || minutes > 59 {
+		return nil, errors.New("offset out of range")
+	}
+
+	seconds := hours*3600 + minutes*60
+	if s[0] == '-' {
+		seconds = -seconds
+	}
+	return time.FixedZone("", seconds), nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Resolution is the least significant component written by FormatDateTime.
+type Resolution int
+
+const (
+	// ResolutionSecond writes a complete date and time, e.g. 2024-03-01T12:30:00Z.
+	ResolutionSecond Resolution = iota
+	// ResolutionMinute writes hours and minutes, e.g. 2024-03-01T12:30Z.
+	ResolutionMinute
+	// ResolutionHour writes hours only, e.g. 2024-03-01T12Z.
+	ResolutionHour
+	// ResolutionDay writes a calendar date, e.g. 2024-03-01.
+	ResolutionDay
+	// ResolutionMonth writes a year and month, e.g. 2024-03.
+	ResolutionMonth
+	// ResolutionYear writes a year, e.g. 2024.
+	ResolutionYear
+)
+
+// DateForm selects the representation of the date written by
+// FormatDateTime.
+type DateForm int
+
+const (
+	// CalendarDate writes year, month and day, e.g. 2024-03-01.
+	CalendarDate DateForm = iota
+	// WeekDateForm writes a week date, e.g. 2024-W09-5.
+	WeekDateForm
+	// OrdinalDateForm writes an ordinal date, e.g. 2024-061.
+	OrdinalDateForm
+)
+
+// DateTimeOptions controls how FormatDateTime writes a time.Time. The zero
+// value writes the extended format to the second, with any fractional
+// seconds in their shortest form, like time.RFC3339Nano.
+type DateTimeOptions struct {
+	// Basic selects the basic format, e.g. 20240301T123000Z, instead of the
+	// extended format, e.g. 2024-03-01T12:30:00Z.
+	Basic bool
+
+	// Resolution selects the least significant component written. For a
+	// WeekDateForm, ResolutionMonth writes the year and week, e.g. 2024-W09;
+	// an OrdinalDateForm has no month, so ResolutionMonth writes the day.
+	Resolution Resolution
+
+	// DateForm selects how the date is written.
+	DateForm DateForm
+
+	// FixedPrecision writes a fraction of the least significant time
+	// component with exactly Precision digits, e.g. 12:30:00.500 for a
+	// Precision of 3, or 12.5 for a ResolutionHour and a Precision of 1.
+	// Otherwise only fractional seconds are written, and without trailing
+	// zeros.
+	FixedPrecision bool
+	Precision      int
+}
+
+// FormatDateTime returns the ISO8601 representation of t, written according
+// to opts. Years outside 0000-9999 are written in the expanded form that
+// ParseDateTime accepts, with a sign and six digits, e.g. +012345; years
+// beyond ±999999 need more digits, and cannot be parsed back.
+//
+// ISO8601 offsets have no seconds, so a t whose offset is not a whole number
+// of minutes, such as a historical local mean time, is written in UTC.
+func FormatDateTime(t time.Time, opts DateTimeOptions) string {
+	var buf [64]byte
+	return string(AppendFormatDateTime(buf[:0], t, opts))
+}
+
+// AppendFormatDateTime appends the ISO8601 representation of t, as returned
+// by FormatDateTime, to dst and returns the extended buffer.
+func AppendFormatDateTime(dst []byte, t time.Time, opts DateTimeOptions) []byte {
+	if _, offset := t.Zone(); offset%60 != 0 {
+		t = t.UTC()
+	}
+	sep := func(c byte) {
+		if !opts.Basic {
+			dst = append(dst, c)
+		}
+	}
+
+	year, month, day := t.Date()
+	switch {
+	case opts.Resolution == ResolutionYear && opts.DateForm == WeekDateForm:
+		return appendYear(dst, WeekDateOf(t).Year)
+	case opts.Resolution == ResolutionYear:
+		return appendYear(dst, year)
+	case opts.DateForm == WeekDateForm:
+		dst = WeekDateOf(t).appendFormat(dst, opts.Basic, opts.Resolution != ResolutionMonth)
+	case opts.DateForm == OrdinalDateForm:
+		dst = OrdinalDateOf(t).appendFormat(dst, opts.Basic)
+	// The basic format has no year-month form, so it always includes the day.
+	case opts.Resolution == ResolutionMonth && !opts.Basic:
+		dst = appendYear(dst, year)
+		sep('-')
+		return appendDigits(dst, int(month), 2)
+	default:
+		dst = appendYear(dst, year)
+		sep('-')
+		dst = appendDigits(dst, int(month), 2)
+		sep('-')
+		dst = appendDigits(dst, day, 2)
+	}
+	if opts.Resolution >= ResolutionDay {
+		return dst
+	}
+
+	hour, minute, second := t.Clock()
+	dst = append(dst, 'T')
+	dst = appendDigits(dst, hour, 2)
+	unit := time.Hour
+	if opts.Resolution <= ResolutionMinute {
+		sep(':')
+		dst = appendDigits(dst, minute, 2)
+		unit = time.Minute
+	}
+	if opts.Resolution == ResolutionSecond {
+		sep(':')
+		dst = appendDigits(dst, second, 2)
+		unit = time.Second
+	}
+	dst = appendFraction(dst, t, unit, opts)
+
+	return appendOffset(dst, t, opts.Basic)
+}
+
+// appendYear appends year in four digits, or in the expanded form with a
+// sign and six digits if it is outside 0000-9999.
+func appendYear(dst []byte, year int) []byte {
+	if year >= 0 && year <= 9999 {
+		return appendDigits(dst, year, 4)
+	}
+	if year < 0 {
+		dst = append(dst, '-')
+		year = -year
+	} else {
+		dst = append(dst, '+')
+	}
+	return appendDigits(dst, year, 6)
+}
+
+// appendFraction appends the fraction of unit elapsed in t since the last
+// whole unit, according to opts.
+func appendFraction(dst []byte, t time.Time, unit time.Duration, opts DateTimeOptions) []byte {
+	_, minute, second := t.Clock()
+	rem := time.Duration(t.Nanosecond())
+	if unit >= time.Minute {
+		rem += time.Duration(second) * time.Second
+	}
+	if unit == time.Hour {
+		rem += time.Duration(minute) * time.Minute
+	}
+
+	digits := opts.Precision
+	if !opts.FixedPrecision {
+		if unit != time.Second || rem == 0 {
+			return dst
+		}
+		digits = 9
+	}
+	if digits <= 0 {
+		return dst
+	}
+
+	// Long division truncates rather than rounds, so that the written time
+	// is never later than t.
+	dst = append(dst, '.')
+	start := len(dst)
+	for range digits {
+		rem *= 10
+		dst = append(dst, byte('0'+rem/unit))
+		rem %= unit
+	}
+	if !opts.FixedPrecision {
+		for len(dst) > start && dst[len(dst)-1] == '0' {
+			dst = dst[:len(dst)-1]
+		}
+	}
+	return dst
+}
+
+func appendOffset(dst []byte, t time.Time, basic bool) []byte {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return append(dst, 'Z')
+	}
+
+	if offset < 0 {
+		dst = append(dst, '-')
+		offset = -offset
+	} else {
+		dst = append(dst, '+')
+	}
+	dst = appendDigits(dst, offset/3600, 2)
+	if !basic {
+		dst = append(dst, ':')
+	}
+	return appendDigits(dst, offset%3600/60, 2)
+}
+
+// appendDigits appends n, zero-padded to at least width digits.
+func appendDigits(dst []byte, n, width int) []byte {
+	var buf [20]byte
+	b := strconv.AppendInt(buf[:0], int64(n), 10)
+	for i := len(b); i < width; i++ {
+		dst = append(dst, '0')
+	}
+	return append(dst, b...)
+}
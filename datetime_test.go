@@ -0,0 +1,170 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanParseDateTime(t *testing.T) {
+	plus5 := time.FixedZone("", 5*3600)
+	cases := []struct {
+		from string
+		want time.Time
+	}{
+		{"2024-03-01T12:00:00Z", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"20240301T120000Z", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"2024-03-01T12:00:00+05", time.Date(2024, 3, 1, 12, 0, 0, 0, plus5)},
+		{"2024-03-01T12:00:00+05:00", time.Date(2024, 3, 1, 12, 0, 0, 0, plus5)},
+		{"20240301T120000+0500", time.Date(2024, 3, 1, 12, 0, 0, 0, plus5)},
+		{"2024-03-01T12:00:00-05:30", time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("", -(5*3600+1800)))},
+		{"2024-03-01T12.5Z", time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{"2024-03-01T12:30,25Z", time.Date(2024, 3, 1, 12, 30, 15, 0, time.UTC)},
+		{"2024-03-01T12:30:15.123456789123Z", time.Date(2024, 3, 1, 12, 30, 15, 123456789, time.UTC)},
+		{"2024-03-01T24:00Z", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-12-31T24:00:00Z", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-03-01T12:30", time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{"2024-03-01T12", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"20240301", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-03", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-02-29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"+012345-01-02T03:04:05Z", time.Date(12345, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"+0123450102T030405Z", time.Date(12345, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"-000005-01-02", time.Date(-5, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"-000005", time.Date(-5, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseDateTime(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if !got.Equal(c.want) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		_, wantOffset := c.want.Zone()
+		if _, gotOffset := got.Zone(); gotOffset != wantOffset {
+			t.Fatalf("Case %d: want offset=%d, got=%d", k, wantOffset, gotOffset)
+		}
+	}
+}
+
+func TestCanParseDateTimeInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := iso8601.ParseDateTimeInLocation("2024-07-01T09:00", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 7, 1, 9, 0, 0, 0, loc)
+	if !got.Equal(want) || got.Location() != loc {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	// An explicit offset takes precedence over the location.
+	got, err = iso8601.ParseDateTimeInLocation("2024-07-01T09:00Z", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestCanRejectBadDateTime(t *testing.T) {
+	cases := []string{
+		"",
+		"24-03-01",
+		"2024-13-01",
+		"2024-02-30",
+		"2023-02-29",
+		"2024-03-01T25:00Z",
+		"2024-03-01T24:00:01Z",
+		"2024-03-01T24.5Z",
+		"2024-03-01T12:60Z",
+		"2024-03-01T12:00+24:00",
+		"2024-03T12:00",
+		"2024-03-01T12:00:00ZZ",
+		"2024-03-01 12:00:00Z",
+		"2024-0301",
+		"20240301T12:00:00Z",
+		"2024-03-01T120000Z",
+		"2024-03-01T12:00+0500",
+		"20240301T1200+05:00",
+		"+12345-01-02",
+		"12345-01-02",
+	}
+
+	for _, c := range cases {
+		if _, err := iso8601.ParseDateTime(c); err == nil {
+			t.Fatalf("%s: Expected error, got none", c)
+		}
+	}
+}
+
+func TestCanFormatDateTime(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 30, 15, 500000000, time.UTC)
+	ist := time.Date(2024, 3, 1, 12, 30, 15, 0, time.FixedZone("IST", 5*3600+1800))
+	cases := []struct {
+		t    time.Time
+		opts iso8601.DateTimeOptions
+		want string
+	}{
+		{ts, iso8601.DateTimeOptions{}, "2024-03-01T12:30:15.5Z"},
+		{ist, iso8601.DateTimeOptions{}, "2024-03-01T12:30:15+05:30"},
+		{ist, iso8601.DateTimeOptions{Basic: true}, "20240301T123015+0530"},
+		{ts, iso8601.DateTimeOptions{Basic: true}, "20240301T123015.5Z"},
+		{ts, iso8601.DateTimeOptions{FixedPrecision: true, Precision: 3}, "2024-03-01T12:30:15.500Z"},
+		{ts, iso8601.DateTimeOptions{FixedPrecision: true}, "2024-03-01T12:30:15Z"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionMinute}, "2024-03-01T12:30Z"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionHour, FixedPrecision: true, Precision: 2},
+			"2024-03-01T12.50Z"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionDay}, "2024-03-01"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionDay, Basic: true}, "20240301"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionMonth}, "2024-03"},
+		{ts, iso8601.DateTimeOptions{Resolution: iso8601.ResolutionYear}, "2024"},
+		{ts.In(time.FixedZone("", -(17*60 + 30))), iso8601.DateTimeOptions{}, "2024-03-01T12:30:15.5Z"},
+		{time.Date(12345, 1, 1, 0, 0, 0, 0, time.UTC), iso8601.DateTimeOptions{Resolution: iso8601.ResolutionDay},
+			"+012345-01-01"},
+	}
+
+	for k, c := range cases {
+		got := iso8601.FormatDateTime(c.t, c.opts)
+		if got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanRoundTripDateTime(t *testing.T) {
+	cases := []time.Time{
+		time.Date(2024, 3, 1, 12, 30, 15, 123456789, time.UTC),
+		time.Date(1999, 12, 31, 23, 59, 59, 0, time.FixedZone("", -8*3600)),
+		time.Date(2024, 3, 1, 0, 0, 0, 1, time.FixedZone("", 5*3600+2700)),
+		time.Date(12345, 6, 7, 8, 9, 10, 0, time.UTC),
+		time.Date(-5, 6, 7, 8, 9, 10, 0, time.UTC),
+		time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("", -(17*60+30))),
+	}
+
+	forms := []iso8601.DateForm{iso8601.CalendarDate, iso8601.WeekDateForm, iso8601.OrdinalDateForm}
+	for k, want := range cases {
+		for _, basic := range []bool{false, true} {
+			for _, form := range forms {
+				s := iso8601.FormatDateTime(want, iso8601.DateTimeOptions{Basic: basic, DateForm: form})
+				got, err := iso8601.ParseDateTime(s)
+				if err != nil {
+					t.Fatalf("Case %d: failed to parse %s: %v", k, s, err)
+				}
+				if !got.Equal(want) {
+					t.Fatalf("Case %d: want=%s, got=%s via %s", k, want, got, s)
+				}
+			}
+		}
+	}
+}
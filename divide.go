@@ -0,0 +1,88 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// MultiplyFloat returns d with all components multiplied by f, with
+// fractional remainders distributed down into smaller units, e.g. P1D
+// multiplied by 0.5 is PT12H and P1Y by 1.5 is P1Y6M.
+//
+// Years carry 12 months, months the average 30.436875 days as in
+// AverageAssumptions, weeks 7 days and days 24 hours. The time part is
+// exact to the nanosecond, and hours are not carried up into days.
+//
+// MultiplyFloat panics if f is NaN or infinite, as the result has no
+// components.
+func (d Duration) MultiplyFloat(f float64) Duration {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic("iso8601: multiplication by a non-finite factor")
+	}
+	return d.scale(f, 1)
+}
+
+// Divide returns d with all components divided by n, with fractional
+// remainders distributed down into smaller units as for MultiplyFloat,
+// e.g. P1D divided by 2 is PT12H and PT1H by 3 is PT20M.
+//
+// Divide panics if n is zero, as integer division does, or if n is NaN or
+// infinite.
+func (d Duration) Divide(n float64) Duration {
+	if n == 0 {
+		panic("iso8601: division by zero")
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		panic("iso8601: division by a non-finite divisor")
+	}
+	return d.scale(1, n)
+}
+
+// scale returns d multiplied by mul/div. Scaling each component before
+// dividing keeps results such as P3D/3 exact.
+func (d Duration) scale(mul, div float64) Duration {
+	var result Duration
+	var fraction float64
+
+	result.Y, fraction = wholeAndFraction(float64(d.Y) * mul / div)
+	result.M, fraction = wholeAndFraction(float64(d.M)*mul/div + fraction*12)
+	months := fraction
+	result.W, fraction = wholeAndFraction(float64(d.W) * mul / div)
+	result.D, fraction = wholeAndFraction(float64(d.D)*mul/div + fraction*7 +
+		months*AverageAssumptions.DaysPerMonth)
+
+	ns := fraction*float64(oneDay) + float64(d.timeDuration())*mul/div
+	rest := FromTimeDuration(time.Duration(math.Round(ns)))
+	result.TH, result.TM, result.TS = rest.TH, rest.TM, rest.TS
+	return result
+}
+
+// wholeAndFraction splits v into its integer part and the remaining
+// fraction, treating values within float rounding error of an integer as
+// that integer.
+func wholeAndFraction(v float64) (int, float64) {
+	if rounded := math.Round(v); math.Abs(v-rounded) < 1e-9 {
+		return int(rounded), 0
+	}
+	whole := math.Trunc(v)
+	return int(whole), v - whole
+}
+
+// DivideBy returns how many times other fits into d, e.g. 3 for PT1H
+// divided by PT20M, or 2.5 for PT50M divided by PT20M. Use math.Floor for
+// whole times only.
+//
+// Both durations must be time-only, as date parts have no fixed length,
+// and other must not be zero.
+func (d Duration) DivideBy(other Duration) (float64, error) {
+	if d.Y != 0 || d.M != 0 || d.W != 0 || d.D != 0 ||
+		other.Y != 0 || other.M != 0 || other.W != 0 || other.D != 0 {
+		return 0, errors.New("cannot divide durations with a date part")
+	}
+	divisor := other.timeDuration()
+	if divisor == 0 {
+		return 0, errors.New("cannot divide by a zero duration")
+	}
+	return float64(d.timeDuration()) / float64(divisor), nil
+}
@@ -0,0 +1,105 @@
+package iso8601_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanMultiplyByFloat(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		f    float64
+		want iso8601.Duration
+	}{
+		{iso8601.Duration{D: 1}, 0.5, iso8601.Duration{TH: 12}},
+		{iso8601.Duration{Y: 1}, 1.5, iso8601.Duration{Y: 1, M: 6}},
+		{iso8601.Duration{W: 1}, 0.5, iso8601.Duration{D: 3, TH: 12}},
+		{iso8601.Duration{M: 1}, 0.5, iso8601.Duration{D: 15, TH: 5, TM: 14, TS: 33}},
+		{iso8601.Duration{TH: 1, TS: 1}, 2.5, iso8601.Duration{TH: 2, TM: 30, TS: 2.5}},
+		{iso8601.Duration{D: 1, TH: 1}, -0.5, iso8601.Duration{TH: -12, TM: -30}},
+		{iso8601.Duration{D: 3}, 1.0 / 3, iso8601.Duration{D: 1}},
+		{iso8601.Duration{D: 2, TH: 3}, 2, iso8601.Duration{D: 4, TH: 6}},
+	}
+	for _, c := range cases {
+		if got := c.d.MultiplyFloat(c.f); !got.Equal(c.want) {
+			t.Fatalf("%s * %g: want=%s, got=%s", c.d, c.f, c.want, got)
+		}
+	}
+}
+
+func TestCanDivide(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		n    float64
+		want iso8601.Duration
+	}{
+		{iso8601.Duration{D: 1}, 2, iso8601.Duration{TH: 12}},
+		{iso8601.Duration{TH: 1}, 3, iso8601.Duration{TM: 20}},
+		{iso8601.Duration{D: 3}, 3, iso8601.Duration{D: 1}},
+		{iso8601.Duration{Y: 1}, 4, iso8601.Duration{M: 3}},
+		{iso8601.Duration{TH: 1}, 7, iso8601.Duration{TM: 8, TS: 34.285714286}},
+		{iso8601.Duration{TM: 1}, -4, iso8601.Duration{TS: -15}},
+	}
+	for _, c := range cases {
+		if got := c.d.Divide(c.n); !got.Equal(c.want) {
+			t.Fatalf("%s / %g: want=%s, got=%s", c.d, c.n, c.want, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Divide by zero should panic")
+		}
+	}()
+	iso8601.Duration{D: 1}.Divide(0)
+}
+
+func TestNonFiniteFactorsPanic(t *testing.T) {
+	d := iso8601.Duration{D: 1}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		ops := map[string]func(float64) iso8601.Duration{"MultiplyFloat": d.MultiplyFloat, "Divide": d.Divide}
+		for name, op := range ops {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%s(%g) should panic", name, f)
+					}
+				}()
+				op(f)
+			}()
+		}
+	}
+}
+
+func TestCanDivideByDuration(t *testing.T) {
+	cases := []struct {
+		d, other iso8601.Duration
+		want     float64
+	}{
+		{iso8601.Duration{TH: 1}, iso8601.Duration{TM: 20}, 3},
+		{iso8601.Duration{TM: 50}, iso8601.Duration{TM: 20}, 2.5},
+		{iso8601.Duration{TH: 1}, iso8601.Duration{TM: -15}, -4},
+		{iso8601.Duration{TS: 1.5}, iso8601.Duration{TS: 0.5}, 3},
+	}
+	for _, c := range cases {
+		got, err := c.d.DivideBy(c.other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("%s / %s: want=%g, got=%g", c.d, c.other, c.want, got)
+		}
+	}
+
+	for _, c := range [][2]iso8601.Duration{
+		{{D: 1}, {TH: 1}},
+		{{TH: 1}, {W: 1}},
+		{{TH: 1}, {}},
+	} {
+		if _, err := c[0].DivideBy(c[1]); err == nil {
+			t.Fatalf("%s / %s: want error", c[0], c[1])
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package iso8601
+
+import (
+	"math"
+	"time"
+)
+
+// Floor returns the latest boundary at or before t, where boundaries are
+// multiples of d from an epoch in t's location, like time.Time.Truncate for
+// calendar durations.
+//
+// For a d with a date part, the epoch is midnight on Monday, January 1st
+// of year 1, so P1D floors to midnight, P1W to Monday, P1M to the start of
+// the month, P3M to the start of the quarter and P1Y to the start of the
+// year. For a time-only d, the epoch is midnight at the start of t's day,
+// so PT1H floors to the local hour and PT15M to the quarter hour.
+//
+// If d does not move forward, or mixes signs and has a date part, Floor
+// returns t unchanged.
+func Floor(t time.Time, d Duration) time.Time {
+	return FloorFrom(t, d, epochFor(t, d))
+}
+
+// Ceil returns the earliest boundary at or after t, where boundaries are as
+// for Floor.
+func Ceil(t time.Time, d Duration) time.Time {
+	return CeilFrom(t, d, epochFor(t, d))
+}
+
+// Round returns the boundary nearest to t, where boundaries are as for
+// Floor. Halfway values are rounded up.
+func Round(t time.Time, d Duration) time.Time {
+	return RoundFrom(t, d, epochFor(t, d))
+}
+
+// FloorFrom returns the latest boundary at or before t, where boundaries are
+// anchor + n*d for any integer n, computed with Shift.
+//
+// If d does not move forward, or mixes signs and has a date part, FloorFrom
+// returns t unchanged.
+func FloorFrom(t time.Time, d Duration, anchor time.Time) time.Time {
+	n, ok := d.boundaryIndex(anchor, t)
+	if !ok {
+		return t
+	}
+	return d.shiftMultiple(anchor, n)
+}
+
+// CeilFrom returns the earliest boundary at or after t, where boundaries
+// are as for FloorFrom.
+func CeilFrom(t time.Time, d Duration, anchor time.Time) time.Time {
+	n, ok := d.boundaryIndex(anchor, t)
+	if !ok {
+		return t
+	}
+	if floor := d.shiftMultiple(anchor, n); floor.Equal(t) {
+		return floor
+	}
+	return d.shiftMultiple(anchor, n+1)
+}
+
+// RoundFrom returns the boundary nearest to t, where boundaries are as for
+// FloorFrom. Halfway values are rounded up.
+func RoundFrom(t time.Time, d Duration, anchor time.Time) time.Time {
+	n, ok := d.boundaryIndex(anchor, t)
+	if !ok {
+		return t
+	}
+	floor := d.shiftMultiple(anchor, n)
+	ceil := d.shiftMultiple(anchor, n+1)
+	if t.Sub(floor) < ceil.Sub(t) {
+		return floor
+	}
+	return ceil
+}
+
+func epochFor(t time.Time, d Duration) time.Time {
+	if d.Y != 0 || d.M != 0 || d.W != 0 || d.D != 0 {
+		// January 1st of year 1 is a Monday in the proleptic Gregorian
+		// calendar.
+		return time.Date(1, time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// boundaryIndex returns the largest n for which anchor + n*d is at or
+// before t, or false if d does not move anchor forward. It also returns
+// false if d mixes signs and has a date part, such as P1M-30D, as the
+// boundaries of such a d need not be in order.
+func (d Duration) boundaryIndex(anchor, t time.Time) (int, bool) {
+	if !d.shiftMultiple(anchor, 1).After(anchor) {
+		return 0, false
+	}
+	if d.Sign() == SignMixed && (d.Y != 0 || d.M != 0 || d.W != 0 || d.D != 0) {
+		return 0, false
+	}
+
+	// Estimate n from average unit lengths, then correct for variable
+	// month and day lengths.
+	var n int
+	if approx := d.TotalSeconds(AverageAssumptions); approx > 0 {
+		elapsed := float64(t.Unix()-anchor.Unix()) + float64(t.Nanosecond()-anchor.Nanosecond())/1e9
+		n = int(math.Floor(elapsed / approx))
+	}
+	for d.shiftMultiple(anchor, n).After(t) {
+		n--
+	}
+	for !d.shiftMultiple(anchor, n+1).After(t) {
+		n++
+	}
+	return n, true
+}
+
+// shiftMultiple returns anchor shifted by n*d, like d.Multiply(n).Shift, but
+// without overflowing the time part when n*d spans centuries.
+func (d Duration) shiftMultiple(anchor time.Time, n int) time.Time {
+	t := anchor
+	if d.Y != 0 || d.M != 0 || d.W != 0 || d.D != 0 {
+		t = t.AddDate(d.Y*n, d.M*n, (d.W*7+d.D)*n)
+	}
+
+	td := d.timeDuration()
+	if td == 0 {
+		return t
+	}
+	limit := int(math.MaxInt64 / int64(max(td, -td)))
+	for n != 0 {
+		step := max(min(n, limit), -limit)
+		t = t.Add(time.Duration(step) * td)
+		n -= step
+	}
+	return t
+}
@@ -0,0 +1,102 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanFloorCeilAndRound(t *testing.T) {
+	ts := time.Date(2024, 8, 14, 15, 40, 30, 0, time.UTC) // a Wednesday
+	date := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		d     iso8601.Duration
+		floor time.Time
+		ceil  time.Time
+		round time.Time
+	}{
+		{iso8601.Duration{Y: 1}, date(2024, 1, 1, 0, 0), date(2025, 1, 1, 0, 0), date(2025, 1, 1, 0, 0)},
+		{iso8601.Duration{M: 3}, date(2024, 7, 1, 0, 0), date(2024, 10, 1, 0, 0), date(2024, 7, 1, 0, 0)},
+		{iso8601.Duration{M: 1}, date(2024, 8, 1, 0, 0), date(2024, 9, 1, 0, 0), date(2024, 8, 1, 0, 0)},
+		{iso8601.Duration{W: 1}, date(2024, 8, 12, 0, 0), date(2024, 8, 19, 0, 0), date(2024, 8, 12, 0, 0)},
+		{iso8601.Duration{D: 1}, date(2024, 8, 14, 0, 0), date(2024, 8, 15, 0, 0), date(2024, 8, 15, 0, 0)},
+		{iso8601.Duration{TH: 1}, date(2024, 8, 14, 15, 0), date(2024, 8, 14, 16, 0), date(2024, 8, 14, 16, 0)},
+		{iso8601.Duration{TM: 15}, date(2024, 8, 14, 15, 30), date(2024, 8, 14, 15, 45), date(2024, 8, 14, 15, 45)},
+	}
+
+	for k, c := range cases {
+		if got := iso8601.Floor(ts, c.d); !got.Equal(c.floor) {
+			t.Fatalf("Case %d: Floor %s: want=%s, got=%s", k, c.d, c.floor, got)
+		}
+		if got := iso8601.Ceil(ts, c.d); !got.Equal(c.ceil) {
+			t.Fatalf("Case %d: Ceil %s: want=%s, got=%s", k, c.d, c.ceil, got)
+		}
+		if got := iso8601.Round(ts, c.d); !got.Equal(c.round) {
+			t.Fatalf("Case %d: Round %s: want=%s, got=%s", k, c.d, c.round, got)
+		}
+		// Boundaries are fixed points.
+		if got := iso8601.Ceil(c.floor, c.d); !got.Equal(c.floor) {
+			t.Fatalf("Case %d: Ceil of boundary: want=%s, got=%s", k, c.floor, got)
+		}
+	}
+}
+
+func TestCanFloorInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2024, 3, 1, 9, 45, 0, 0, loc)
+
+	if got, want := iso8601.Floor(ts, iso8601.Duration{TH: 1}), time.Date(2024, 3, 1, 9, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	if got, want := iso8601.Floor(ts, iso8601.Duration{D: 1}), time.Date(2024, 3, 1, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The day after the spring DST change still floors to local midnight.
+	ts = time.Date(2024, 3, 11, 12, 0, 0, 0, ny)
+	if got, want := iso8601.Floor(ts, iso8601.Duration{D: 1}), time.Date(2024, 3, 11, 0, 0, 0, 0, ny); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestCanFloorFromAnchor(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC)
+	ts := time.Date(2024, 3, 1, 9, 45, 0, 0, time.UTC)
+	d := iso8601.Duration{TM: 30}
+
+	if got, want := iso8601.FloorFrom(ts, d, anchor), time.Date(2024, 3, 1, 9, 40, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	if got, want := iso8601.CeilFrom(ts, d, anchor), time.Date(2024, 3, 1, 10, 10, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	if got, want := iso8601.RoundFrom(ts, d, anchor), time.Date(2024, 3, 1, 9, 40, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	// A billing cycle anchored on the 15th.
+	billing := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+	got := iso8601.FloorFrom(ts, iso8601.Duration{M: 1}, billing)
+	if want := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestFloorIgnoresNonPositiveDuration(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 9, 45, 0, 0, time.UTC)
+	for _, d := range []iso8601.Duration{{}, {D: -1}} {
+		if got := iso8601.Floor(ts, d); !got.Equal(ts) {
+			t.Fatalf("%s: want=%s, got=%s", d, ts, got)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package iso8601
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// ZeroFormat selects how FormatOptions writes the zero duration.
+type ZeroFormat int
+
+const (
+	// ZeroDays writes the zero duration as P0D.
+	ZeroDays ZeroFormat = iota
+	// ZeroSeconds writes the zero duration as PT0S.
+	ZeroSeconds
+)
+
+// FormatOptions controls how a Duration is written by FormatWith and
+// AppendFormatWith. The zero value produces the same output as String.
+type FormatOptions struct {
+	// Zero selects the representation of the zero duration.
+	Zero ZeroFormat
+
+	// FixedPrecision writes seconds with exactly Precision fractional
+	// digits, e.g. PT1.500S for a Precision of 3. Otherwise seconds are
+	// written in their shortest representation.
+	FixedPrecision bool
+	Precision      int
+
+	// NoExponent prevents seconds from being written in exponent notation,
+	// e.g. PT0.0000001S rather than PT1e-07S.
+	NoExponent bool
+
+	// WeeksAsDays folds weeks into days, e.g. P1W2D is written as P9D.
+	WeeksAsDays bool
+
+	// ExplicitZero writes every component, including zero ones, e.g.
+	// P0Y0M0W1DT0H0M0S.
+	ExplicitZero bool
+}
+
+// String returns an ISO8601-ish representation of the duration.
+func (d Duration) String() string {
+	var buf [64]byte
+	return string(d.AppendFormat(buf[:0]))
+}
+
+// AppendFormat appends the ISO8601 representation of d, as returned by
+// String, to dst and returns the extended buffer.
+//
+// AppendFormat does not allocate when dst has sufficient capacity.
+func (d Duration) AppendFormat(dst []byte) []byte {
+	return d.AppendFormatWith(dst, FormatOptions{})
+}
+
+// FormatWith returns the ISO8601 representation of d, written according to
+// opts.
+func (d Duration) FormatWith(opts FormatOptions) string {
+	var buf [64]byte
+	return string(d.AppendFormatWith(buf[:0], opts))
+}
+
+// AppendFormatWith appends the ISO8601 representation of d, written
+// according to opts, to dst and returns the extended buffer.
+func (d Duration) AppendFormatWith(dst []byte, opts FormatOptions) []byte {
+	if opts.WeeksAsDays {
+		d.D += d.W * 7
+		d.W = 0
+	}
+
+	explicit := opts.ExplicitZero
+	if d.IsZero() && !explicit {
+		if opts.Zero == ZeroSeconds {
+			dst = append(dst, "PT"...)
+			dst = opts.appendSeconds(dst, 0)
+			return append(dst, 'S')
+		}
+		return append(dst, "P0D"...)
+	}
+
+	// A duration whose components share a sign is written with a single
+	// leading minus. Mixed signs are written per component, as described in
+	// ISO 8601-2, e.g. P1DT-2H.
+	sign := d.Sign()
+	mixed := sign == SignMixed
+	if sign == SignNegative {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, 'P')
+	dst = appendInt(dst, d.Y, 'Y', explicit, mixed)
+	dst = appendInt(dst, d.M, 'M', explicit, mixed)
+	if !opts.WeeksAsDays {
+		dst = appendInt(dst, d.W, 'W', explicit, mixed)
+	}
+	dst = appendInt(dst, d.D, 'D', explicit, mixed)
+	if d.HasTimePart() || explicit {
+		dst = append(dst, 'T')
+	}
+	dst = appendInt(dst, d.TH, 'H', explicit, mixed)
+	dst = appendInt(dst, d.TM, 'M', explicit, mixed)
+	if d.TS != 0 || explicit {
+		if mixed && d.TS < 0 {
+			dst = append(dst, '-')
+		}
+		dst = opts.appendSeconds(dst, absFloat(d.TS))
+		dst = append(dst, 'S')
+	}
+
+	return dst
+}
+
+// Format satisfies fmt.Formatter. The supported verbs are:
+//
+//	%v, %s  the ISO8601 representation, as returned by String
+//	%q      the ISO8601 representation, double-quoted
+//	%+v     the ISO8601 representation with zero components written out,
+//	        e.g. P0Y0M0W1DT0H0M0S
+//	%#v     Go syntax, e.g. iso8601.Duration{D:1}
+//
+// A precision sets the number of fractional digits written for seconds, e.g.
+// %.3s formats PT1.5S as PT1.500S. A width pads the result with spaces, on
+// the left unless the '-' flag is given.
+func (d Duration) Format(f fmt.State, verb rune) {
+	var buf [128]byte
+	b := buf[:0]
+
+	var opts FormatOptions
+	opts.Precision, opts.FixedPrecision = f.Precision()
+
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			b = d.appendGoSyntax(b)
+		case f.Flag('+'):
+			opts.ExplicitZero = true
+			b = d.AppendFormatWith(b, opts)
+		default:
+			b = d.AppendFormatWith(b, opts)
+		}
+	case 's':
+		b = d.AppendFormatWith(b, opts)
+	case 'q':
+		b = strconv.AppendQuote(b, d.FormatWith(opts))
+	default:
+		b = append(b, "%!"...)
+		b = append(b, string(verb)...)
+		b = append(b, "(iso8601.Duration="...)
+		b = d.AppendFormat(b)
+		b = append(b, ')')
+	}
+
+	writePadded(f, b)
+}
+
+// appendGoSyntax appends a Go composite literal for d, listing only its
+// non-zero fields.
+func (d Duration) appendGoSyntax(dst []byte) []byte {
+	dst = append(dst, "iso8601.Duration{"...)
+	sep := false
+	field := func(name string) {
+		if sep {
+			dst = append(dst, ", "...)
+		}
+		sep = true
+		dst = append(dst, name...)
+		dst = append(dst, ':')
+	}
+	for _, c := range []struct {
+		name string
+		n    int
+	}{{"Y", d.Y}, {"M", d.M}, {"W", d.W}, {"D", d.D}, {"TH", d.TH}, {"TM", d.TM}} {
+		if c.n != 0 {
+			field(c.name)
+			dst = strconv.AppendInt(dst, int64(c.n), 10)
+		}
+	}
+	if d.TS != 0 {
+		field("TS")
+		dst = strconv.AppendFloat(dst, d.TS, 'g', -1, 64)
+	}
+	return append(dst, '}')
+}
+
+// writePadded writes b to f, padded with spaces to the width of f, if any.
+func writePadded(f fmt.State, b []byte) {
+	if width, ok := f.Width(); ok && width > len(b) {
+		pad := bytes.Repeat([]byte{' '}, width-len(b))
+		if f.Flag('-') {
+			b = append(b, pad...)
+		} else {
+			b = append(pad, b...)
+		}
+	}
+	_, _ = f.Write(b) //nolint:errcheck // fmt.Formatter has no way to report errors
+}
+
+// appendInt appends the absolute value of n followed by the designator,
+// or nothing if n is zero and explicit is not set. If signed is set, a
+// negative n is prefixed with a minus sign.
+func appendInt(dst []byte, n int, designator byte, explicit, signed bool) []byte {
+	if n == 0 && !explicit {
+		return dst
+	}
+	if n < 0 {
+		if signed {
+			dst = append(dst, '-')
+		}
+		// Avoid -n, which overflows for math.MinInt.
+		dst = strconv.AppendUint(dst, uint64(-(n+1))+1, 10)
+	} else {
+		dst = strconv.AppendUint(dst, uint64(n), 10)
+	}
+	return append(dst, designator)
+}
+
+// appendSeconds appends ts with the configured precision. By default ts is
+// appended without a fractional part if it is integral, and in its shortest
+// representation otherwise.
+func (o FormatOptions) appendSeconds(dst []byte, ts float64) []byte {
+	switch {
+	case o.FixedPrecision:
+		return strconv.AppendFloat(dst, ts, 'f', max(o.Precision, 0), 64)
+	case ts == float64(int64(ts)):
+		return strconv.AppendFloat(dst, ts, 'f', 0, 64)
+	case o.NoExponent:
+		return strconv.AppendFloat(dst, ts, 'f', -1, 64)
+	default:
+		return strconv.AppendFloat(dst, ts, 'g', -1, 64)
+	}
+}
+
+func absFloat(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,235 @@
+package iso8601_test
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"testing"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+// legacyTmpl is the html/template based formatter String used to be built
+// on. It is kept here as an oracle for the output of AppendFormat for
+// durations whose components share a sign.
+var legacyTmpl = template.Must(template.New("duration").Funcs(template.FuncMap{
+	"formatSeconds": func(ts float64) string {
+		if ts == float64(int64(ts)) {
+			return fmt.Sprintf("%.0f", ts)
+		}
+		return fmt.Sprintf("%g", ts)
+	},
+	"isNegative": func(d iso8601.Duration) bool {
+		return d.Y < 0 || d.M < 0 || d.W < 0 || d.D < 0 || d.TH < 0 || d.TM < 0 || d.TS < 0
+	},
+	"abs": func(n int) int {
+		if n < 0 {
+			return -n
+		}
+		return n
+	},
+	"absFloat": func(f float64) float64 {
+		if f < 0 {
+			return -f
+		}
+		return f
+	},
+}).Parse(
+	`{{if isNegative .}}-{{end}}P{{if .Y}}{{abs .Y}}Y{{end}}{{if .M}}{{abs .M}}M{{end}}` +
+		`{{if .W}}{{abs .W}}W{{end}}{{if .D}}{{abs .D}}D{{end}}{{if .HasTimePart}}T{{end }}` +
+		`{{if .TH}}{{abs .TH}}H{{end}}{{if .TM}}{{abs .TM}}M{{end}}{{if .TS}}{{formatSeconds (absFloat .TS)}}S{{end}}`))
+
+func legacyString(t *testing.T, d iso8601.Duration) string {
+	if d.IsZero() {
+		return "P0D"
+	}
+	var s bytes.Buffer
+	if err := legacyTmpl.Execute(&s, d); err != nil {
+		t.Fatal(err)
+	}
+	// The template HTML-escaped its output, e.g. "+" in exponents became
+	// "&#43;"; AppendFormat writes plain text.
+	return html.UnescapeString(s.String())
+}
+
+func TestCanFormatLikeLegacyTemplate(t *testing.T) {
+	cases := []iso8601.Duration{
+		{},
+		{Y: 1},
+		{M: 2},
+		{W: 3},
+		{D: 4},
+		{TH: 5},
+		{TM: 6},
+		{TS: 7},
+		{Y: 1, M: 2, W: 3, D: 4, TH: 5, TM: 6, TS: 7},
+		{D: 343, TH: 13, TM: 8, TS: 33.3444},
+		{TS: 0.5},
+		{TS: 1.999999},
+		{TS: 1e-7},
+		{TS: 123456789.125},
+		{TS: 1e21},
+		{Y: -1, M: -2, D: -3, TH: -4, TM: -5, TS: -6},
+		{TS: -33.3444},
+		{Y: math.MaxInt, TS: math.MaxFloat64},
+		{Y: -math.MaxInt},
+		{TS: math.Inf(1)},
+		{TS: math.NaN()},
+	}
+
+	for k, c := range cases {
+		want := legacyString(t, c)
+		if got := c.String(); got != want {
+			t.Fatalf("Case %d: String: want=%s, got=%s", k, want, got)
+		}
+		if got := string(c.AppendFormat(nil)); got != want {
+			t.Fatalf("Case %d: AppendFormat: want=%s, got=%s", k, want, got)
+		}
+	}
+}
+
+func TestCanAppendFormat(t *testing.T) {
+	d := iso8601.Duration{D: 1, TH: 2}
+	got := string(d.AppendFormat([]byte("duration=")))
+	want := "duration=P1DT2H"
+	if got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestCanFormatMinInt(t *testing.T) {
+	d := iso8601.Duration{D: math.MinInt64}
+	want := "-P9223372036854775808D"
+	if got := d.String(); got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestAppendFormatDoesNotAllocate(t *testing.T) {
+	d := iso8601.Duration{Y: 1, M: 2, W: 3, D: 4, TH: 5, TM: 6, TS: 7.25}
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = d.AppendFormat(buf[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("want=0 allocs, got=%v", allocs)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	d := iso8601.Duration{Y: 1, M: 2, W: 3, D: 4, TH: 5, TM: 6, TS: 7.25}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = d.String()
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	d := iso8601.Duration{Y: 1, M: 2, W: 3, D: 4, TH: 5, TM: 6, TS: 7.25}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = d.AppendFormat(buf[:0])
+	}
+}
+
+func TestCanFormatWithVerbs(t *testing.T) {
+	d := iso8601.Duration{D: 1, TH: 2, TS: 1.5}
+	cases := []struct {
+		format string
+		d      iso8601.Duration
+		want   string
+	}{
+		{"%v", d, "P1DT2H1.5S"},
+		{"%s", d, "P1DT2H1.5S"},
+		{"%q", d, `"P1DT2H1.5S"`},
+		{"%+v", d, "P0Y0M0W1DT2H0M1.5S"},
+		{"%+v", iso8601.Duration{}, "P0Y0M0W0DT0H0M0S"},
+		{"%+v", iso8601.Duration{D: -1}, "-P0Y0M0W1DT0H0M0S"},
+		{"%#v", d, "iso8601.Duration{D:1, TH:2, TS:1.5}"},
+		{"%#v", iso8601.Duration{}, "iso8601.Duration{}"},
+		{"%#v", iso8601.Duration{Y: -1, TS: -0.25}, "iso8601.Duration{Y:-1, TS:-0.25}"},
+		{"%.3s", d, "P1DT2H1.500S"},
+		{"%.0v", iso8601.Duration{TS: 1.25}, "PT1S"},
+		{"%.2q", iso8601.Duration{TS: 1}, `"PT1.00S"`},
+		{"%12s", iso8601.Duration{D: 1}, "         P1D"},
+		{"%-12s|", iso8601.Duration{D: 1}, "P1D         |"},
+		{"%2s", d, "P1DT2H1.5S"},
+		{"%d", iso8601.Duration{D: 1}, "%!d(iso8601.Duration=P1D)"},
+	}
+
+	for k, c := range cases {
+		got := fmt.Sprintf(c.format, c.d)
+		if got != c.want {
+			t.Fatalf("Case %d (%s): want=%s, got=%s", k, c.format, c.want, got)
+		}
+	}
+}
+
+func TestCanFormatPointer(t *testing.T) {
+	d := &iso8601.Duration{W: 2}
+	want := "P2W"
+	if got := fmt.Sprintf("%v", d); got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestCanFormatWithOptions(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		opts iso8601.FormatOptions
+		want string
+	}{
+		{iso8601.Duration{}, iso8601.FormatOptions{}, "P0D"},
+		{iso8601.Duration{}, iso8601.FormatOptions{Zero: iso8601.ZeroSeconds}, "PT0S"},
+		{iso8601.Duration{}, iso8601.FormatOptions{Zero: iso8601.ZeroSeconds, FixedPrecision: true, Precision: 2},
+			"PT0.00S"},
+		{iso8601.Duration{TS: 1.5}, iso8601.FormatOptions{FixedPrecision: true, Precision: 3}, "PT1.500S"},
+		{iso8601.Duration{TS: 1.5}, iso8601.FormatOptions{FixedPrecision: true}, "PT2S"},
+		{iso8601.Duration{TS: 1e-7}, iso8601.FormatOptions{}, "PT1e-07S"},
+		{iso8601.Duration{TS: 1e-7}, iso8601.FormatOptions{NoExponent: true}, "PT0.0000001S"},
+		{iso8601.Duration{TS: 1e21 + 0.5}, iso8601.FormatOptions{NoExponent: true}, "PT1000000000000000000000S"},
+		{iso8601.Duration{W: 1, D: 2}, iso8601.FormatOptions{}, "P1W2D"},
+		{iso8601.Duration{W: 1, D: 2}, iso8601.FormatOptions{WeeksAsDays: true}, "P9D"},
+		{iso8601.Duration{W: -2, TH: -1}, iso8601.FormatOptions{WeeksAsDays: true}, "-P14DT1H"},
+		{iso8601.Duration{D: 1}, iso8601.FormatOptions{ExplicitZero: true}, "P0Y0M0W1DT0H0M0S"},
+		{iso8601.Duration{D: 1}, iso8601.FormatOptions{ExplicitZero: true, WeeksAsDays: true}, "P0Y0M1DT0H0M0S"},
+	}
+
+	for k, c := range cases {
+		if got := c.d.FormatWith(c.opts); got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		if got := string(c.d.AppendFormatWith(nil, c.opts)); got != c.want {
+			t.Fatalf("Case %d: AppendFormatWith: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanFormatMixedSigns(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		want string
+	}{
+		{iso8601.Duration{D: 1, TH: -2}, "P1DT-2H"},
+		{iso8601.Duration{D: -1, TH: 2}, "P-1DT2H"},
+		{iso8601.Duration{Y: 1, M: -1, TS: -0.5}, "P1Y-1MT-0.5S"},
+		{iso8601.Duration{D: -1, TH: -2}, "-P1DT2H"},
+	}
+
+	for k, c := range cases {
+		got := c.d.String()
+		if got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		back, err := iso8601.ParseISO8601(got)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, got, err)
+		}
+		if !back.Equal(c.d) {
+			t.Fatalf("Case %d: want=%+v, got=%+v", k, c.d, back)
+		}
+	}
+}
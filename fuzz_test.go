@@ -0,0 +1,86 @@
+package iso8601_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+// Seed inputs live in testdata/fuzz; the f.Add calls below cover the
+// interesting shapes so that plain `go test` exercises them too.
+
+func FuzzParseISO8601(f *testing.F) {
+	for _, s := range []string{"P1D", "-P1Y2M3W4DT5H6M7.5S", "P1DT-2H", "PT1e-07S", "-P9223372036854775808D"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := iso8601.ParseISO8601(s)
+		if err != nil {
+			return
+		}
+
+		formatted := d.String()
+		got, err := iso8601.ParseISO8601(formatted)
+		if err != nil {
+			t.Fatalf("%q parsed to %+v, but its String %q did not parse: %v", s, d, formatted, err)
+		}
+		if !got.Equal(d) {
+			t.Fatalf("%q: want=%+v, got=%+v via %q", s, d, got, formatted)
+		}
+		if again := got.String(); again != formatted {
+			t.Fatalf("%q: String is not stable: %q, then %q", s, formatted, again)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, s := range []string{`"P1D"`, `"-PT0.5S"`, `"P1DT-2H"`, `null`, `{"foo":"bar"}`, `"P"`} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var d iso8601.Duration
+		if err := json.Unmarshal(b, &d); err != nil {
+			return
+		}
+
+		out, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal %+v: %v", b, d, err)
+		}
+		var got iso8601.Duration
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("%s: failed to unmarshal %s: %v", b, out, err)
+		}
+		if !got.Equal(d) {
+			t.Fatalf("%s: want=%+v, got=%+v via %s", b, d, got, out)
+		}
+	})
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(1, 2, 3, 4, 5, 6, 7.5)
+	f.Add(1, 0, 0, 0, -2, 0, 0.0)
+	f.Add(0, 0, 0, 0, 0, 0, 1e-7)
+	f.Add(math.MinInt, 0, 0, 0, 0, 0, 0.0)
+	f.Add(0, 0, 0, 0, 0, math.MaxInt, -math.MaxFloat64)
+
+	f.Fuzz(func(t *testing.T, y, m, w, d, th, tm int, ts float64) {
+		if math.IsNaN(ts) || math.IsInf(ts, 0) {
+			t.Skip("seconds are not representable in ISO8601")
+		}
+		want := iso8601.Duration{Y: y, M: m, W: w, D: d, TH: th, TM: tm, TS: ts}
+
+		s := want.String()
+		got, err := iso8601.ParseISO8601(s)
+		if err != nil {
+			t.Fatalf("%+v: failed to parse %q: %v", want, s, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("want=%+v, got=%+v via %q", want, got, s)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package iso8601
+
+import (
+	"slices"
+	"time"
+)
+
+// FixedDate is the HolidayRule for a holiday on the same date every year,
+// e.g. {time.December, 25}.
+type FixedDate struct {
+	Month time.Month
+	Day   int
+}
+
+// Date returns the fixed date in the given year, or false if it does not
+// exist that year, e.g. February 29th outside leap years.
+func (r FixedDate) Date(year int) (Date, bool) {
+	d := Date{Year: year, Month: r.Month, Day: r.Day}
+	return d, d.IsValid()
+}
+
+// EasterOffset is the HolidayRule for a holiday relative to Western Easter
+// Sunday, e.g. {Duration{D: -2}} for Good Friday. Only the date part of
+// Offset is used.
+type EasterOffset struct {
+	Offset Duration
+}
+
+// Date returns the holiday in the given year.
+func (r EasterOffset) Date(year int) (Date, bool) {
+	return Easter(year).Shift(r.Offset), true
+}
+
+// Easter returns the date of Western Easter Sunday in the given year of the
+// Gregorian calendar.
+func Easter(year int) Date {
+	// The anonymous Gregorian algorithm.
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Date{Year: year, Month: time.Month(month), Day: day}
+}
+
+// Observance is how a holiday falling on a Saturday or Sunday is observed
+// on a weekday instead.
+type Observance int
+
+const (
+	// ObserveNearestWeekday moves a Saturday holiday to the Friday before
+	// and a Sunday holiday to the Monday after, as for US federal holidays.
+	ObserveNearestWeekday Observance = iota
+	// ObserveFollowingWeekday moves a Saturday or Sunday holiday to the
+	// Monday after, as for UK bank holidays.
+	ObserveFollowingWeekday
+)
+
+// Observed is the HolidayRule for the observed date of another rule's
+// holiday, substituting a weekday when it falls on a weekend.
+//
+// If the observed date coincides with a holiday of any rule in Avoid, it
+// moves on to the next weekday that does not, e.g. to observe Boxing Day on
+// Tuesday when Christmas is observed on Monday.
+type Observed struct {
+	Rule       HolidayRule
+	Observance Observance
+	Avoid      []HolidayRule
+}
+
+// Date returns the observed date of the holiday in the given year, which
+// may fall in the previous or next year, e.g. a Saturday New Year's Day
+// observed on December 31st.
+func (r Observed) Date(year int) (Date, bool) {
+	d, ok := r.Rule.Date(year)
+	if !ok {
+		return Date{}, false
+	}
+
+	switch weekday := d.In(time.UTC).Weekday(); {
+	case weekday == time.Saturday && r.Observance == ObserveNearestWeekday:
+		d = d.Unshift(Duration{D: 1})
+	case weekday == time.Saturday:
+		d = d.Shift(Duration{D: 2})
+	case weekday == time.Sunday:
+		d = d.Shift(Duration{D: 1})
+	}
+
+	for r.avoids(d) {
+		d = d.Shift(Duration{D: 1})
+		for wd := d.In(time.UTC).Weekday(); wd == time.Saturday || wd == time.Sunday; wd = d.In(time.UTC).Weekday() {
+			d = d.Shift(Duration{D: 1})
+		}
+	}
+	return d, true
+}
+
+func (r Observed) avoids(d Date) bool {
+	for _, rule := range r.Avoid {
+		if other, ok := rule.Date(d.Year); ok && other == d {
+			return true
+		}
+	}
+	return false
+}
+
+// YearRange is the HolidayRule that restricts another rule to the years
+// from From to To inclusive. Zero leaves that end unbounded.
+type YearRange struct {
+	Rule     HolidayRule
+	From, To int
+}
+
+// Date returns the holiday in the given year, or false if the year is out
+// of range.
+func (r YearRange) Date(year int) (Date, bool) {
+	if (r.From != 0 && year < r.From) || (r.To != 0 && year > r.To) {
+		return Date{}, false
+	}
+	return r.Rule.Date(year)
+}
+
+// USFederalCalendar returns a BusinessCalendar with a Saturday and Sunday
+// weekend and the US federal holidays, observed on the nearest weekday.
+func USFederalCalendar() BusinessCalendar {
+	observed := func(rule HolidayRule) HolidayRule {
+		return Observed{Rule: rule, Observance: ObserveNearestWeekday}
+	}
+	return BusinessCalendar{Rules: []HolidayRule{
+		observed(FixedDate{time.January, 1}),
+		NthWeekday{time.January, time.Monday, 3},  // Martin Luther King Jr. Day
+		NthWeekday{time.February, time.Monday, 3}, // Washington's Birthday
+		NthWeekday{time.May, time.Monday, -1},     // Memorial Day
+		YearRange{Rule: observed(FixedDate{time.June, 19}), From: 2021},
+		observed(FixedDate{time.July, 4}),
+		NthWeekday{time.September, time.Monday, 1},  // Labor Day
+		NthWeekday{time.October, time.Monday, 2},    // Columbus Day
+		observed(FixedDate{time.November, 11}),      // Veterans Day
+		NthWeekday{time.November, time.Thursday, 4}, // Thanksgiving Day
+		observed(FixedDate{time.December, 25}),
+	}}
+}
+
+// EnglandWalesCalendar returns a BusinessCalendar with a Saturday and Sunday
+// weekend and the regular bank holidays of England and Wales, with weekend
+// holidays substituted by the following weekday. One-off bank holidays are
+// not included.
+func EnglandWalesCalendar() BusinessCalendar {
+	christmas := FixedDate{time.December, 25}
+	boxingDay := FixedDate{time.December, 26}
+	observedChristmas := Observed{Rule: christmas, Observance: ObserveFollowingWeekday, Avoid: []HolidayRule{boxingDay}}
+	return BusinessCalendar{Rules: []HolidayRule{
+		Observed{Rule: FixedDate{time.January, 1}, Observance: ObserveFollowingWeekday},
+		EasterOffset{Duration{D: -2}},         // Good Friday
+		EasterOffset{Duration{D: 1}},          // Easter Monday
+		NthWeekday{time.May, time.Monday, 1},  // Early May bank holiday
+		NthWeekday{time.May, time.Monday, -1}, // Spring bank holiday
+		NthWeekday{time.August, time.Monday, -1},
+		observedChristmas,
+		Observed{Rule: boxingDay, Observance: ObserveFollowingWeekday, Avoid: []HolidayRule{observedChristmas}},
+	}}
+}
+
+// GermanyCalendar returns a BusinessCalendar with a Saturday and Sunday
+// weekend and the nationwide public holidays of Germany. Holidays of
+// individual states are not included, and none are substituted.
+func GermanyCalendar() BusinessCalendar {
+	return BusinessCalendar{Rules: []HolidayRule{
+		FixedDate{time.January, 1},
+		EasterOffset{Duration{D: -2}}, // Karfreitag
+		EasterOffset{Duration{D: 1}},  // Ostermontag
+		FixedDate{time.May, 1},
+		EasterOffset{Duration{D: 39}}, // Christi Himmelfahrt
+		EasterOffset{Duration{D: 50}}, // Pfingstmontag
+		FixedDate{time.October, 3},
+		FixedDate{time.December, 25},
+		FixedDate{time.December, 26},
+	}}
+}
+
+// HolidaysIn returns the holidays of cal that fall in the given year, in
+// order, whether or not they fall on a weekend.
+func (c BusinessCalendar) HolidaysIn(year int) []Date {
+	var holidays []Date
+	for _, h := range c.Holidays {
+		if h.Year == year {
+			holidays = append(holidays, h)
+		}
+	}
+	// Observed holidays may move into an adjacent year.
+	for y := year - 1; y <= year+1; y++ {
+		for _, rule := range c.Rules {
+			if h, ok := rule.Date(y); ok && h.Year == year {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	slices.SortFunc(holidays, Date.Compare)
+	return slices.Compact(holidays)
+}
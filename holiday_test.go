@@ -0,0 +1,119 @@
+package iso8601_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestEaster(t *testing.T) {
+	cases := map[int]iso8601.Date{
+		2000: {Year: 2000, Month: time.April, Day: 23},
+		2019: {Year: 2019, Month: time.April, Day: 21},
+		2024: {Year: 2024, Month: time.March, Day: 31},
+		2025: {Year: 2025, Month: time.April, Day: 20},
+		2038: {Year: 2038, Month: time.April, Day: 25},
+	}
+	for year, want := range cases {
+		if got := iso8601.Easter(year); got != want {
+			t.Fatalf("%d: want=%s, got=%s", year, want, got)
+		}
+	}
+
+	goodFriday, _ := iso8601.EasterOffset{Offset: iso8601.Duration{D: -2}}.Date(2024)
+	if want := (iso8601.Date{Year: 2024, Month: time.March, Day: 29}); goodFriday != want {
+		t.Fatalf("want=%s, got=%s", want, goodFriday)
+	}
+}
+
+func TestFixedDateSkipsMissingDates(t *testing.T) {
+	leapDay := iso8601.FixedDate{Month: time.February, Day: 29}
+	if _, ok := leapDay.Date(2023); ok {
+		t.Fatal("2023-02-29 should not exist")
+	}
+	if got, ok := leapDay.Date(2024); !ok || got != (iso8601.Date{Year: 2024, Month: time.February, Day: 29}) {
+		t.Fatalf("want=2024-02-29, got=%s %t", got, ok)
+	}
+}
+
+func TestObservedHolidays(t *testing.T) {
+	newYear := iso8601.FixedDate{Month: time.January, Day: 1}
+	cases := []struct {
+		rule iso8601.Observed
+		year int
+		want iso8601.Date
+	}{
+		// 2022-01-01 is a Saturday.
+		{
+			iso8601.Observed{Rule: newYear, Observance: iso8601.ObserveNearestWeekday},
+			2022, iso8601.Date{Year: 2021, Month: time.December, Day: 31},
+		},
+		{
+			iso8601.Observed{Rule: newYear, Observance: iso8601.ObserveFollowingWeekday},
+			2022, iso8601.Date{Year: 2022, Month: time.January, Day: 3},
+		},
+		// 2023-01-01 is a Sunday.
+		{
+			iso8601.Observed{Rule: newYear, Observance: iso8601.ObserveNearestWeekday},
+			2023, iso8601.Date{Year: 2023, Month: time.January, Day: 2},
+		},
+		{
+			iso8601.Observed{Rule: newYear, Observance: iso8601.ObserveFollowingWeekday},
+			2024, iso8601.Date{Year: 2024, Month: time.January, Day: 1},
+		},
+	}
+	for _, c := range cases {
+		if got, ok := c.rule.Date(c.year); !ok || got != c.want {
+			t.Fatalf("%d: want=%s, got=%s %t", c.year, c.want, got, ok)
+		}
+	}
+}
+
+func TestBuiltInCalendars(t *testing.T) {
+	dates := func(year int, monthDays ...int) []iso8601.Date {
+		var result []iso8601.Date
+		for i := 0; i < len(monthDays); i += 2 {
+			result = append(result, iso8601.Date{Year: year, Month: time.Month(monthDays[i]), Day: monthDays[i+1]})
+		}
+		return result
+	}
+	cases := []struct {
+		name string
+		cal  iso8601.BusinessCalendar
+		year int
+		want []iso8601.Date
+	}{
+		{"US", iso8601.USFederalCalendar(), 2021, dates(2021,
+			1, 1, 1, 18, 2, 15, 5, 31, 6, 18, 7, 5, 9, 6, 10, 11, 11, 11, 11, 25, 12, 24, 12, 31)},
+		{"US", iso8601.USFederalCalendar(), 2020, dates(2020,
+			1, 1, 1, 20, 2, 17, 5, 25, 7, 3, 9, 7, 10, 12, 11, 11, 11, 26, 12, 25)},
+		{"England and Wales", iso8601.EnglandWalesCalendar(), 2021, dates(2021,
+			1, 1, 4, 2, 4, 5, 5, 3, 5, 31, 8, 30, 12, 27, 12, 28)},
+		{"England and Wales", iso8601.EnglandWalesCalendar(), 2022, dates(2022,
+			1, 3, 4, 15, 4, 18, 5, 2, 5, 30, 8, 29, 12, 26, 12, 27)},
+		{"Germany", iso8601.GermanyCalendar(), 2024, dates(2024,
+			1, 1, 3, 29, 4, 1, 5, 1, 5, 9, 5, 20, 10, 3, 12, 25, 12, 26)},
+	}
+	for _, c := range cases {
+		if got := c.cal.HolidaysIn(c.year); !slices.Equal(got, c.want) {
+			t.Fatalf("%s %d: want=%v, got=%v", c.name, c.year, c.want, got)
+		}
+	}
+}
+
+func TestCanShiftByBusinessDaysOverEaster(t *testing.T) {
+	cal := iso8601.GermanyCalendar()
+	thursday := time.Date(2024, time.March, 28, 9, 0, 0, 0, time.UTC)
+
+	got := iso8601.Duration{D: 2}.ShiftBusiness(thursday, cal)
+	if want := time.Date(2024, time.April, 3, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+
+	// The Saturday-observed New Year's Day is a holiday of the year before.
+	if iso8601.USFederalCalendar().IsBusinessDay(iso8601.Date{Year: 2021, Month: time.December, Day: 31}) {
+		t.Fatal("2021-12-31 should be a US federal holiday")
+	}
+}
@@ -0,0 +1,252 @@
+package iso8601
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Interval represents the half-open time interval [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IntervalFrom returns the interval starting at start and lasting d, with
+// its end resolved by d.Shift(start).
+func IntervalFrom(start time.Time, d Duration) Interval {
+	return Interval{Start: start, End: d.Shift(start)}
+}
+
+// IntervalUntil returns the interval lasting d and ending at end, with its
+// start resolved by d.Unshift(end).
+func IntervalUntil(d Duration, end time.Time) Interval {
+	return Interval{Start: d.Unshift(end), End: end}
+}
+
+// ParseInterval parses an ISO8601 time interval in one of the forms
+// start/end, start/duration or duration/end, e.g.
+// 2024-03-01T09:00Z/PT8H. Either "/" or "--" may separate the two parts.
+//
+// Date-times without an offset are interpreted as UTC, and durations are
+// resolved against the other endpoint with Shift or Unshift.
+func ParseInterval(from string) (Interval, error) {
+	first, second, ok := strings.Cut(from, "/")
+	if !ok {
+		first, second, ok = strings.Cut(from, "--")
+	}
+	if !ok {
+		return Interval{}, errors.New("could not parse interval string")
+	}
+
+	isDuration := func(s string) bool {
+		return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P")
+	}
+
+	switch {
+	case isDuration(first) && isDuration(second):
+		return Interval{}, errors.New("interval requires at least one date-time")
+	case isDuration(second):
+		start, err := ParseDateTime(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		d, err := ParseISO8601(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return IntervalFrom(start, d), nil
+	case isDuration(first):
+		d, err := ParseISO8601(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		end, err := ParseDateTime(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return IntervalUntil(d, end), nil
+	default:
+		start, err := ParseDateTime(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		end, err := ParseDateTime(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		return Interval{Start: start, End: end}, nil
+	}
+}
+
+// IsEmpty reports whether i contains no instants, i.e. its end is not after
+// its start.
+func (i Interval) IsEmpty() bool {
+	return !i.End.After(i.Start)
+}
+
+// Contains reports whether t is in i.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Overlaps reports whether i and other have any instant in common.
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End) && !i.IsEmpty() && !other.IsEmpty()
+}
+
+// Intersect returns the instants i and other have in common, which is empty
+// if they do not overlap.
+func (i Interval) Intersect(other Interval) Interval {
+	return Interval{Start: laterOf(i.Start, other.Start), End: earlierOf(i.End, other.End)}
+}
+
+// String returns the start/end representation of i, e.g.
+// 2024-03-01T09:00:00Z/2024-03-01T17:00:00Z.
+func (i Interval) String() string {
+	var buf [80]byte
+	dst := AppendFormatDateTime(buf[:0], i.Start, DateTimeOptions{})
+	dst = append(dst, '/')
+	return string(AppendFormatDateTime(dst, i.End, DateTimeOptions{}))
+}
+
+// IntervalSet is a set of instants, held as sorted, disjoint, non-adjacent
+// and non-empty intervals. The zero value is the empty set.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewIntervalSet returns the set of instants in any of the given intervals.
+// The intervals are normalized: empty intervals are dropped, and
+// overlapping or adjacent ones are merged.
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		if !i.IsEmpty() {
+			sorted = append(sorted, i)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	var merged []Interval
+	for _, i := range sorted {
+		if n := len(merged); n > 0 && !i.Start.After(merged[n-1].End) {
+			merged[n-1].End = laterOf(merged[n-1].End, i.End)
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return IntervalSet{intervals: merged}
+}
+
+// Intervals returns the normalized intervals of s, in order.
+func (s IntervalSet) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+// IsEmpty reports whether s contains no instants.
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Span returns the smallest interval containing all of s, which is empty if
+// s is.
+func (s IntervalSet) Span() Interval {
+	if s.IsEmpty() {
+		return Interval{}
+	}
+	return Interval{Start: s.intervals[0].Start, End: s.intervals[len(s.intervals)-1].End}
+}
+
+// Contains reports whether t is in s.
+func (s IntervalSet) Contains(t time.Time) bool {
+	// Find the last interval starting at or before t.
+	n, _ := slices.BinarySearchFunc(s.intervals, t, func(i Interval, t time.Time) int {
+		if i.Start.After(t) {
+			return 1
+		}
+		return -1
+	})
+	return n > 0 && s.intervals[n-1].Contains(t)
+}
+
+// Overlaps reports whether s and i have any instant in common.
+func (s IntervalSet) Overlaps(i Interval) bool {
+	for _, j := range s.intervals {
+		if j.Overlaps(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the instants in either s or other.
+func (s IntervalSet) Union(other IntervalSet) IntervalSet {
+	return NewIntervalSet(append(s.Intervals(), other.intervals...)...)
+}
+
+// Intersect returns the instants in both s and other.
+func (s IntervalSet) Intersect(other IntervalSet) IntervalSet {
+	var result []Interval
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 && len(b) > 0 {
+		if common := a[0].Intersect(b[0]); !common.IsEmpty() {
+			result = append(result, common)
+		}
+		// Drop whichever interval ends first; it cannot overlap anything else.
+		if a[0].End.Before(b[0].End) {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return IntervalSet{intervals: result}
+}
+
+// Subtract returns the instants in s but not in other.
+func (s IntervalSet) Subtract(other IntervalSet) IntervalSet {
+	var result []Interval
+	b := other.intervals
+	for _, i := range s.intervals {
+		// Skip intervals of other that end before i starts.
+		for len(b) > 0 && !b[0].End.After(i.Start) {
+			b = b[1:]
+		}
+		for _, j := range b {
+			if !j.Start.Before(i.End) {
+				break
+			}
+			if j.Start.After(i.Start) {
+				result = append(result, Interval{Start: i.Start, End: j.Start})
+			}
+			i.Start = laterOf(i.Start, j.End)
+		}
+		if !i.IsEmpty() {
+			result = append(result, i)
+		}
+	}
+	return IntervalSet{intervals: result}
+}
+
+// Gaps returns the instants within the given interval that are not in s.
+// Use s.Gaps(s.Span()) for the gaps between the intervals of s.
+func (s IntervalSet) Gaps(within Interval) IntervalSet {
+	return NewIntervalSet(within).Subtract(s)
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,127 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+// at returns 2024-03-01 at the given hour, in UTC.
+func at(hour int) time.Time {
+	return time.Date(2024, 3, 1, hour, 0, 0, 0, time.UTC)
+}
+
+func span(from, to int) iso8601.Interval {
+	return iso8601.Interval{Start: at(from), End: at(to)}
+}
+
+func assertIntervals(t *testing.T, name string, got iso8601.IntervalSet, want ...iso8601.Interval) {
+	t.Helper()
+	intervals := got.Intervals()
+	if len(intervals) != len(want) {
+		t.Fatalf("%s: want=%v, got=%v", name, want, intervals)
+	}
+	for k := range want {
+		if !intervals[k].Start.Equal(want[k].Start) || !intervals[k].End.Equal(want[k].End) {
+			t.Fatalf("%s: want=%v, got=%v", name, want, intervals)
+		}
+	}
+}
+
+func TestCanParseInterval(t *testing.T) {
+	cases := []struct {
+		from string
+		want iso8601.Interval
+	}{
+		{"2024-03-01T09:00Z/2024-03-01T17:00Z", span(9, 17)},
+		{"2024-03-01T09:00Z/PT8H", span(9, 17)},
+		{"PT8H/2024-03-01T17:00Z", span(9, 17)},
+		{"20240301T0900Z--20240301T1700Z", span(9, 17)},
+		{"2024-01-31/P1M", iso8601.Interval{
+			Start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseInterval(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if !got.Start.Equal(c.want.Start) || !got.End.Equal(c.want.End) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+
+	for _, bad := range []string{"", "2024-03-01", "P1D/P2D", "2024-03-01/PZ", "nope/2024-03-01"} {
+		if _, err := iso8601.ParseInterval(bad); err == nil {
+			t.Fatalf("%s: Expected error, got none", bad)
+		}
+	}
+}
+
+func TestCanStringifyInterval(t *testing.T) {
+	want := "2024-03-01T09:00:00Z/2024-03-01T17:00:00Z"
+	if got := span(9, 17).String(); got != want {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestIntervalContainsAndOverlaps(t *testing.T) {
+	i := span(9, 17)
+	if !i.Contains(at(9)) || i.Contains(at(17)) || i.Contains(at(8)) {
+		t.Fatal("Contains should treat the interval as half-open")
+	}
+	if !i.Overlaps(span(16, 18)) || i.Overlaps(span(17, 18)) || i.Overlaps(span(10, 10)) {
+		t.Fatal("unexpected Overlaps result")
+	}
+	if got := i.Intersect(span(16, 18)); !got.Start.Equal(at(16)) || !got.End.Equal(at(17)) {
+		t.Fatalf("want=%s, got=%s", span(16, 17), got)
+	}
+	if !i.Intersect(span(18, 19)).IsEmpty() {
+		t.Fatal("expected empty intersection")
+	}
+}
+
+func TestCanNormalizeIntervalSet(t *testing.T) {
+	s := iso8601.NewIntervalSet(span(13, 15), span(9, 10), span(14, 16), span(10, 11), span(20, 20))
+	assertIntervals(t, "normalize", s, span(9, 11), span(13, 16))
+	assertIntervals(t, "span", iso8601.NewIntervalSet(s.Span()), span(9, 16))
+
+	if !iso8601.NewIntervalSet().IsEmpty() || !iso8601.NewIntervalSet(span(3, 2)).IsEmpty() {
+		t.Fatal("expected empty set")
+	}
+}
+
+func TestIntervalSetContains(t *testing.T) {
+	s := iso8601.NewIntervalSet(span(9, 11), span(13, 16))
+	cases := []struct {
+		hour int
+		want bool
+	}{
+		{8, false}, {9, true}, {10, true}, {11, false}, {12, false}, {13, true}, {15, true}, {16, false}, {20, false},
+	}
+	for _, c := range cases {
+		if got := s.Contains(at(c.hour)); got != c.want {
+			t.Fatalf("%02d:00: want=%v, got=%v", c.hour, c.want, got)
+		}
+	}
+	if !s.Overlaps(span(10, 12)) || s.Overlaps(span(11, 13)) {
+		t.Fatal("unexpected Overlaps result")
+	}
+}
+
+func TestCanCombineIntervalSets(t *testing.T) {
+	a := iso8601.NewIntervalSet(span(9, 12), span(13, 17))
+	b := iso8601.NewIntervalSet(span(8, 10), span(11, 14), span(16, 18))
+
+	assertIntervals(t, "union", a.Union(b), span(8, 18))
+	assertIntervals(t, "intersect", a.Intersect(b), span(9, 10), span(11, 12), span(13, 14), span(16, 17))
+	assertIntervals(t, "subtract", a.Subtract(b), span(10, 11), span(14, 16))
+	assertIntervals(t, "subtract reverse", b.Subtract(a), span(8, 9), span(12, 13), span(17, 18))
+	assertIntervals(t, "subtract all", a.Subtract(iso8601.NewIntervalSet(span(0, 23))))
+	assertIntervals(t, "subtract none", a.Subtract(iso8601.IntervalSet{}), span(9, 12), span(13, 17))
+	assertIntervals(t, "gaps", a.Gaps(a.Span()), span(12, 13))
+	assertIntervals(t, "gaps within", a.Gaps(span(6, 20)), span(6, 9), span(12, 13), span(17, 20))
+}
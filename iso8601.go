@@ -1,12 +1,10 @@
-// Package iso8601 handles ISO8601-formatted durations.
+// Package iso8601 handles ISO8601-formatted durations, dates and times.
 package iso8601
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"html/template"
+	"math"
 	"regexp"
 	"strconv"
 	"time"
@@ -26,11 +24,17 @@ type Duration struct {
 }
 
 var pattern = regexp.MustCompile(
-	`^(-)?P((?P<year>\d+)Y)?((?P<month>\d+)M)?((?P<week>\d+)W)?((?P<day>\d+)D)?` +
-		`(T((?P<hour>\d+)H)?((?P<minute>\d+)M)?((?P<second>\d+(?:\.\d+)?)S)?)?$`)
+	`^(-)?P((?P<year>[-+]?\d+)Y)?((?P<month>[-+]?\d+)M)?((?P<week>[-+]?\d+)W)?((?P<day>[-+]?\d+)D)?` +
+		`(T((?P<hour>[-+]?\d+)H)?((?P<minute>[-+]?\d+)M)?((?P<second>[-+]?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?)S)?)?$`)
 
 // ParseISO8601 parses an ISO8601 duration string.
-// Supports negative durations with a leading minus sign (e.g., -P1D).
+// Supports negative durations with a leading minus sign (e.g., -P1D), and
+// signed components as described in ISO 8601-2 (e.g., P1DT-2H). A leading
+// minus sign negates every component, so -P1DT-2H is the same as P-1DT2H.
+//
+// Seconds may be written in exponent notation (e.g., PT1e-07S), as String
+// does for very small or very large values, so that any Duration with finite
+// seconds survives a round trip through String and ParseISO8601.
 //
 //nolint:gocyclo // Complex parsing logic is necessary for ISO8601 format
 func ParseISO8601(from string) (Duration, error) {
@@ -54,6 +58,11 @@ func ParseISO8601(from string) (Duration, error) {
 		if i == 0 || name == "" || part == "" {
 			continue
 		}
+		// Negate before converting, so that the magnitude of math.MinInt,
+		// as written by String, is still in range.
+		if negative {
+			part = negateNumber(part)
+		}
 
 		switch name {
 		case "year", "month", "week", "day", "hour", "minute": //nolint:goconst // These are field names, not constants
@@ -61,9 +70,6 @@ func ParseISO8601(from string) (Duration, error) {
 			if err != nil {
 				return d, err
 			}
-			if negative {
-				val = -val
-			}
 			switch name {
 			case "year":
 				d.Y = val
@@ -83,9 +89,6 @@ func ParseISO8601(from string) (Duration, error) {
 			if err != nil {
 				return d, err
 			}
-			if negative {
-				val = -val
-			}
 			d.TS = val
 		}
 	}
@@ -93,16 +96,96 @@ func ParseISO8601(from string) (Duration, error) {
 	return d, nil
 }
 
+// negateNumber flips the sign of a signed decimal number string.
+func negateNumber(s string) string {
+	switch s[0] {
+	case '-':
+		return s[1:]
+	case '+':
+		return "-" + s[1:]
+	default:
+		return "-" + s
+	}
+}
+
 // IsZero reports whether d represents the zero duration, P0D.
 func (d Duration) IsZero() bool {
 	return d.Y == 0 && d.M == 0 && d.W == 0 && d.D == 0 && d.TH == 0 && d.TM == 0 && d.TS == 0.0
 }
 
-// IsNegative returns true if the duration is negative.
+// IsNegative reports whether any component of d is negative. It is also
+// true for a mixed-sign duration such as P1DT-2H; use Sign to tell those
+// apart from wholly negative durations.
 func (d Duration) IsNegative() bool {
 	return d.Y < 0 || d.M < 0 || d.W < 0 || d.D < 0 || d.TH < 0 || d.TM < 0 || d.TS < 0
 }
 
+// Sign describes the signs of the components of a Duration.
+type Sign int
+
+const (
+	// SignNegative is the sign of a duration with negative components only.
+	SignNegative Sign = -1
+	// SignZero is the sign of the zero duration.
+	SignZero Sign = 0
+	// SignPositive is the sign of a duration with positive components only.
+	SignPositive Sign = 1
+	// SignMixed is the sign of a duration with both positive and negative
+	// components, e.g. P1DT-2H.
+	SignMixed Sign = 2
+)
+
+// Sign returns SignNegative, SignZero or SignPositive if the components of
+// d share a sign, ignoring zeros, and SignMixed if they do not.
+func (d Duration) Sign() Sign {
+	positive := d.Y > 0 || d.M > 0 || d.W > 0 || d.D > 0 || d.TH > 0 || d.TM > 0 || d.TS > 0
+	switch negative := d.IsNegative(); {
+	case positive && negative:
+		return SignMixed
+	case positive:
+		return SignPositive
+	case negative:
+		return SignNegative
+	default:
+		return SignZero
+	}
+}
+
+// Abs returns d with the sign of its largest non-zero component made
+// positive, which is -d if d.Sign() is SignNegative and d if it is
+// SignPositive or SignZero. A mixed-sign duration may remain mixed, e.g.
+// Abs(P-1DT2H) is P1DT-2H; use Canonical first to resolve its components to
+// a single sign.
+func (d Duration) Abs() Duration {
+	components := []float64{
+		float64(d.Y), float64(d.M), float64(d.W), float64(d.D),
+		float64(d.TH), float64(d.TM), d.TS,
+	}
+	for _, c := range components {
+		if c < 0 {
+			return d.Negate()
+		}
+		if c > 0 {
+			break
+		}
+	}
+	return d
+}
+
+// Canonical returns d with mixed signs resolved against the reference date
+// ref, as the single-sign duration that shifts ref to the same time, e.g.
+// P1DT-2H is PT22H, and P1M-1D from 2024-01-01 is P30D. Durations that are
+// not mixed are returned unchanged.
+//
+// The result is in years, months, days, hours, minutes and seconds, as by
+// FromTimeDurationAt.
+func (d Duration) Canonical(ref time.Time) Duration {
+	if d.Sign() != SignMixed {
+		return d
+	}
+	return FromTimeDurationAt(d.Shift(ref).Sub(ref), ref)
+}
+
 // Negate returns a new Duration with all components negated.
 func (d Duration) Negate() Duration {
 	return Duration{
@@ -159,55 +242,12 @@ func (d Duration) timeDuration() time.Duration {
 	var dur time.Duration
 	dur += time.Duration(d.TH) * time.Hour
 	dur += time.Duration(d.TM) * time.Minute
-	// Convert fractional seconds to nanoseconds
-	dur += time.Duration(d.TS * float64(time.Second))
+	// Convert fractional seconds to the nearest nanosecond, as the product
+	// may fall short, e.g. 16.350295721 seconds is 16350295720.999998ns.
+	dur += time.Duration(math.Round(d.TS * float64(time.Second)))
 	return dur
 }
 
-var tmpl = template.Must(template.New("duration").Funcs(template.FuncMap{
-	"formatSeconds": func(ts float64) string {
-		if ts == float64(int64(ts)) {
-			return fmt.Sprintf("%.0f", ts)
-		}
-		// Remove trailing zeros
-		return fmt.Sprintf("%g", ts)
-	},
-	"isNegative": func(d Duration) bool {
-		return d.Y < 0 || d.M < 0 || d.W < 0 || d.D < 0 || d.TH < 0 || d.TM < 0 || d.TS < 0
-	},
-	"abs": func(n int) int {
-		if n < 0 {
-			return -n
-		}
-		return n
-	},
-	"absFloat": func(f float64) float64 {
-		if f < 0 {
-			return -f
-		}
-		return f
-	},
-}).Parse(
-	`{{if isNegative .}}-{{end}}P{{if .Y}}{{abs .Y}}Y{{end}}{{if .M}}{{abs .M}}M{{end}}` +
-		`{{if .W}}{{abs .W}}W{{end}}{{if .D}}{{abs .D}}D{{end}}{{if .HasTimePart}}T{{end }}` +
-		`{{if .TH}}{{abs .TH}}H{{end}}{{if .TM}}{{abs .TM}}M{{end}}{{if .TS}}{{formatSeconds (absFloat .TS)}}S{{end}}`))
-
-// String returns an ISO8601-ish representation of the duration.
-func (d Duration) String() string {
-	var s bytes.Buffer
-
-	if d.IsZero() {
-		return "P0D"
-	}
-
-	err := tmpl.Execute(&s, d)
-	if err != nil {
-		panic(err)
-	}
-
-	return s.String()
-}
-
 // MarshalJSON satisfies json.Marshaler.
 func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.String())
@@ -319,7 +359,9 @@ func (d Duration) GreaterThan(other Duration) bool {
 }
 
 // ToTimeDuration converts the time component of d to a time.Duration.
-// Date components (years, months, weeks, days) are ignored.
+// Date components (years, months, weeks, days) are ignored. Fractional
+// seconds are rounded to the nearest nanosecond, as they are by Shift and
+// Unshift, so PT16.350295721S is exactly 16350295721ns.
 func (d Duration) ToTimeDuration() time.Duration {
 	return d.timeDuration()
 }
@@ -327,16 +369,5 @@ func (d Duration) ToTimeDuration() time.Duration {
 // FromTimeDuration creates a Duration from a time.Duration.
 // Only the time component is set; date components are zero.
 func FromTimeDuration(td time.Duration) Duration {
-	d := Duration{}
-	hours := td / time.Hour
-	td -= hours * time.Hour
-	minutes := td / time.Minute
-	td -= minutes * time.Minute
-	seconds := float64(td) / float64(time.Second)
-
-	d.TH = int(hours)
-	d.TM = int(minutes)
-	d.TS = seconds
-
-	return d
+	return FromTimeDurationWith(td, TimeDurationOptions{})
 }
@@ -143,6 +143,8 @@ func TestCanRejectBadString(t *testing.T) {
 		"PP1D",
 		"P1D2F",
 		"P2F",
+		"P--1D",
+		"P-D",
 	}
 
 	for _, c := range cases {
@@ -344,6 +346,30 @@ func TestCanParseNegativeDurations(t *testing.T) {
 	}
 }
 
+func TestCanParseSignedComponents(t *testing.T) {
+	cases := []struct {
+		from string
+		want iso8601.Duration
+	}{
+		{"P1DT-2H", iso8601.Duration{D: 1, TH: -2}},
+		{"P-1DT2H", iso8601.Duration{D: -1, TH: 2}},
+		{"P+1D", iso8601.Duration{D: 1}},
+		{"-P1DT-2H", iso8601.Duration{D: -1, TH: 2}},
+		{"-P-1D", iso8601.Duration{D: 1}},
+		{"PT1M-0.5S", iso8601.Duration{TM: 1, TS: -0.5}},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseISO8601(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if !got.Equal(c.want) {
+			t.Fatalf("Case %d: want=%+v, got=%+v", k, c.want, got)
+		}
+	}
+}
+
 func TestCanStringifyNegativeDurations(t *testing.T) {
 	cases := []struct {
 		from string
@@ -482,6 +508,8 @@ func TestCanConvertToTimeDuration(t *testing.T) {
 		{iso8601.Duration{TS: 1.5}, 1500 * time.Millisecond},
 		{iso8601.Duration{TH: 1, TM: 30, TS: 45}, 1*time.Hour + 30*time.Minute + 45*time.Second},
 		{iso8601.Duration{TH: -1}, -time.Hour},
+		{iso8601.Duration{TS: 16.350295721}, 16350295721 * time.Nanosecond},
+		{iso8601.Duration{TS: -16.350295721}, -16350295721 * time.Nanosecond},
 	}
 
 	for k, c := range cases {
@@ -492,6 +520,18 @@ func TestCanConvertToTimeDuration(t *testing.T) {
 	}
 }
 
+func TestShiftRoundsFractionalSeconds(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := iso8601.Duration{TS: 16.350295721}
+	want := from.Add(16350295721 * time.Nanosecond)
+	if got := d.Shift(from); !got.Equal(want) {
+		t.Fatalf("Shift: want=%s, got=%s", want, got)
+	}
+	if got := d.Unshift(want); !got.Equal(from) {
+		t.Fatalf("Unshift: want=%s, got=%s", from, got)
+	}
+}
+
 func TestCanConvertFromTimeDuration(t *testing.T) {
 	cases := []struct {
 		td   time.Duration
@@ -553,3 +593,66 @@ func TestIsNegative(t *testing.T) {
 		}
 	}
 }
+
+func TestSign(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		want iso8601.Sign
+	}{
+		{iso8601.Duration{}, iso8601.SignZero},
+		{iso8601.Duration{D: 1, TH: 2}, iso8601.SignPositive},
+		{iso8601.Duration{D: -1, TS: -0.5}, iso8601.SignNegative},
+		{iso8601.Duration{D: 1, TH: -2}, iso8601.SignMixed},
+		{iso8601.Duration{Y: -1, TS: 0.5}, iso8601.SignMixed},
+	}
+
+	for k, c := range cases {
+		if got := c.d.Sign(); got != c.want {
+			t.Fatalf("Case %d: want=%v, got=%v", k, c.want, got)
+		}
+	}
+}
+
+func TestAbs(t *testing.T) {
+	cases := []struct {
+		d    iso8601.Duration
+		want iso8601.Duration
+	}{
+		{iso8601.Duration{}, iso8601.Duration{}},
+		{iso8601.Duration{D: 1, TH: 2}, iso8601.Duration{D: 1, TH: 2}},
+		{iso8601.Duration{D: -1, TS: -0.5}, iso8601.Duration{D: 1, TS: 0.5}},
+		{iso8601.Duration{D: 1, TH: -2}, iso8601.Duration{D: 1, TH: -2}},
+		{iso8601.Duration{D: -1, TH: 2}, iso8601.Duration{D: 1, TH: -2}},
+	}
+
+	for k, c := range cases {
+		if got := c.d.Abs(); !got.Equal(c.want) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		d    iso8601.Duration
+		want iso8601.Duration
+	}{
+		{iso8601.Duration{D: 1, TH: -2}, iso8601.Duration{TH: 22}},
+		{iso8601.Duration{D: -1, TH: 2}, iso8601.Duration{TH: -22}},
+		{iso8601.Duration{M: 1, D: -1}, iso8601.Duration{D: 30}},
+		{iso8601.Duration{Y: 1, M: -1, TM: 30}, iso8601.Duration{M: 11, TM: 30}},
+		{iso8601.Duration{W: 1, D: 2}, iso8601.Duration{W: 1, D: 2}},
+		{iso8601.Duration{D: -3}, iso8601.Duration{D: -3}},
+	}
+
+	for k, c := range cases {
+		got := c.d.Canonical(ref)
+		if !got.Equal(c.want) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		if got.Sign() == iso8601.SignMixed || !got.Shift(ref).Equal(c.d.Shift(ref)) {
+			t.Fatalf("Case %d: %s is not equivalent to %s", k, got, c.d)
+		}
+	}
+}
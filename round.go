@@ -0,0 +1,162 @@
+package iso8601
+
+import (
+	"math"
+	"time"
+)
+
+// Truncate returns d with the components smaller than unit dropped, e.g.
+// PT1H23M45.678S truncated to UnitMinute is PT1H23M. Fractional seconds
+// are dropped for UnitSecond.
+//
+// The smaller components are first folded into unit, assuming average
+// lengths for days, months and years as in AverageAssumptions, so P1DT30H
+// truncated to UnitDay is P2D. Use TruncateAt for exact calendar units.
+//
+// The result is then normalised from unit upwards: for UnitSecond and
+// UnitMinute, seconds carry into minutes and minutes into hours, and for
+// UnitMonth, months carry into years, so PT90M truncated to UnitMinute is
+// PT1H30M. Mixed signs among those components are resolved, so PT1H-1M
+// truncated to UnitMinute is PT59M. Hours and days never carry, as days and
+// weeks are not of fixed length in every location.
+func (d Duration) Truncate(unit Unit) Duration {
+	return d.round(unit, truncate, nil)
+}
+
+// Round returns d rounded to a whole number of unit, halfway values away
+// from zero, e.g. PT1H23M45.678S rounded to UnitMinute is PT1H24M.
+//
+// The smaller components are folded into unit, and the result normalised,
+// as for Truncate, so PT1H59M59.9S rounded to UnitSecond is PT2H and PT90S
+// is PT1M30S.
+func (d Duration) Round(unit Unit) Duration {
+	return d.round(unit, roundHalfAway, nil)
+}
+
+// TruncateAt is like Truncate, but folds smaller components into a calendar
+// unit by its exact length once the larger components are applied to ref,
+// e.g. PT24H truncated to UnitDay is P1D, except from the start of a
+// 25-hour day, where it is P0D. For time units, it is the same as Truncate.
+func (d Duration) TruncateAt(unit Unit, ref time.Time) Duration {
+	return d.round(unit, truncate, &ref)
+}
+
+// RoundAt is like Round, but folds smaller components into a calendar unit
+// by its exact length, as for TruncateAt, e.g. P1M15D rounded to UnitMonth
+// is P2M from January 1st 2024, as 15 days are more than half of February,
+// but P1M from February 1st 2024, as they are less than half of March.
+func (d Duration) RoundAt(unit Unit, ref time.Time) Duration {
+	return d.round(unit, roundHalfAway, &ref)
+}
+
+// round returns upper + k units, where k is whole applied to the rest of d
+// below unit, measured in units. The rest may have the opposite sign to the
+// whole of d, e.g. in PT2H-1M, so whole rounds relative to the sign of d
+// rather than of the rest.
+func (d Duration) round(unit Unit, whole func(units float64, negative bool) float64, ref *time.Time) Duration {
+	upper, rest := d.splitAt(unit)
+
+	var units float64
+	var negative bool
+	if ref != nil && unit >= UnitDay {
+		// Measure the rest in units of the exact length it spans once the
+		// larger components are applied to ref.
+		one := unitDuration(unit)
+		base := upper.Shift(*ref)
+		end := rest.Shift(base)
+		n, _ := one.boundaryIndex(base, end)
+		from, to := one.shiftMultiple(base, n), one.shiftMultiple(base, n+1)
+		units = float64(n) + float64(end.Sub(from))/float64(to.Sub(from))
+		negative = end.Before(*ref)
+	} else {
+		units = rest.TotalSeconds(AverageAssumptions) / unitDuration(unit).TotalSeconds(AverageAssumptions)
+		negative = d.TotalSeconds(AverageAssumptions) < 0
+	}
+
+	return upper.Add(unitDuration(unit).Multiply(int(whole(units, negative)))).carry(unit)
+}
+
+// truncate returns the whole number of units to add so that the result is
+// no further from zero than the unrounded value, whose sign is given.
+func truncate(units float64, negative bool) float64 {
+	if negative {
+		return math.Ceil(units)
+	}
+	return math.Floor(units)
+}
+
+// roundHalfAway returns the whole number of units nearest to units, with
+// halfway values away from zero for an unrounded value of the given sign.
+func roundHalfAway(units float64, negative bool) float64 {
+	if negative {
+		return math.Ceil(units - 0.5)
+	}
+	return math.Floor(units + 0.5)
+}
+
+// splitAt splits d into the components of at least unit, with whole
+// seconds only for UnitSecond, and the smaller rest.
+func (d Duration) splitAt(unit Unit) (upper, rest Duration) {
+	upper = d
+	switch unit {
+	case UnitYear:
+		rest.M, upper.M = upper.M, 0
+		fallthrough
+	case UnitMonth:
+		rest.W, upper.W = upper.W, 0
+		fallthrough
+	case UnitWeek:
+		rest.D, upper.D = upper.D, 0
+		fallthrough
+	case UnitDay:
+		rest.TH, upper.TH = upper.TH, 0
+		fallthrough
+	case UnitHour:
+		rest.TM, upper.TM = upper.TM, 0
+		fallthrough
+	case UnitMinute:
+		rest.TS, upper.TS = upper.TS, 0
+	case UnitSecond:
+		upper.TS = math.Trunc(d.TS)
+		rest.TS = d.TS - upper.TS
+	}
+	return upper, rest
+}
+
+// unitDuration returns the Duration of one unit.
+func unitDuration(unit Unit) Duration {
+	switch unit {
+	case UnitYear:
+		return Duration{Y: 1}
+	case UnitMonth:
+		return Duration{M: 1}
+	case UnitWeek:
+		return Duration{W: 1}
+	case UnitDay:
+		return Duration{D: 1}
+	case UnitHour:
+		return Duration{TH: 1}
+	case UnitMinute:
+		return Duration{TM: 1}
+	default:
+		return Duration{TS: 1}
+	}
+}
+
+// carry normalises d from unit upwards, so that hours, minutes and whole
+// seconds for time units below an hour, or years and months for UnitMonth,
+// are in range and share the sign of their total, e.g. PT1M-1S is PT59S.
+func (d Duration) carry(unit Unit) Duration {
+	switch unit {
+	case UnitSecond, UnitMinute:
+		seconds := float64(d.TH)*3600 + float64(d.TM)*60 + d.TS
+		d.TH = int(seconds / 3600)
+		seconds -= float64(d.TH) * 3600
+		d.TM = int(seconds / 60)
+		d.TS = seconds - float64(d.TM)*60
+	case UnitMonth:
+		months := d.Y*12 + d.M
+		d.Y, d.M = months/12, months%12
+	}
+	return d
+}
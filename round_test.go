@@ -0,0 +1,188 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanRoundAndTruncateDurations(t *testing.T) {
+	cases := []struct {
+		d        iso8601.Duration
+		unit     iso8601.Unit
+		truncate iso8601.Duration
+		round    iso8601.Duration
+	}{
+		{
+			iso8601.Duration{TH: 1, TM: 23, TS: 45.678}, iso8601.UnitMinute,
+			iso8601.Duration{TH: 1, TM: 23}, iso8601.Duration{TH: 1, TM: 24},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: 23, TS: 45.678}, iso8601.UnitSecond,
+			iso8601.Duration{TH: 1, TM: 23, TS: 45}, iso8601.Duration{TH: 1, TM: 23, TS: 46},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: 23, TS: 45.678}, iso8601.UnitHour,
+			iso8601.Duration{TH: 1}, iso8601.Duration{TH: 1},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: 59, TS: 59.9}, iso8601.UnitSecond,
+			iso8601.Duration{TH: 1, TM: 59, TS: 59}, iso8601.Duration{TH: 2},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: 59, TS: 30}, iso8601.UnitMinute,
+			iso8601.Duration{TH: 1, TM: 59}, iso8601.Duration{TH: 2},
+		},
+		{
+			iso8601.Duration{TH: 23, TM: 30}, iso8601.UnitHour,
+			iso8601.Duration{TH: 23}, iso8601.Duration{TH: 24},
+		},
+		{
+			iso8601.Duration{D: 1, TH: 30}, iso8601.UnitDay,
+			iso8601.Duration{D: 2}, iso8601.Duration{D: 2},
+		},
+		{
+			iso8601.Duration{D: 1, TH: 12}, iso8601.UnitDay,
+			iso8601.Duration{D: 1}, iso8601.Duration{D: 2},
+		},
+		{
+			iso8601.Duration{W: 1, D: 4}, iso8601.UnitWeek,
+			iso8601.Duration{W: 1}, iso8601.Duration{W: 2},
+		},
+		{
+			iso8601.Duration{M: 11, D: 20}, iso8601.UnitMonth,
+			iso8601.Duration{M: 11}, iso8601.Duration{Y: 1},
+		},
+		{
+			iso8601.Duration{Y: 1, M: 7}, iso8601.UnitYear,
+			iso8601.Duration{Y: 1}, iso8601.Duration{Y: 2},
+		},
+		{
+			iso8601.Duration{TH: -1, TM: -23, TS: -45.678}, iso8601.UnitMinute,
+			iso8601.Duration{TH: -1, TM: -23}, iso8601.Duration{TH: -1, TM: -24},
+		},
+		{
+			iso8601.Duration{TM: -59, TS: -30}, iso8601.UnitMinute,
+			iso8601.Duration{TM: -59}, iso8601.Duration{TH: -1},
+		},
+		{
+			iso8601.Duration{}, iso8601.UnitDay,
+			iso8601.Duration{}, iso8601.Duration{},
+		},
+		// Results are normalised from unit upwards whether or not rounding
+		// carried.
+		{
+			iso8601.Duration{TM: 75, TS: 59.6}, iso8601.UnitSecond,
+			iso8601.Duration{TH: 1, TM: 15, TS: 59}, iso8601.Duration{TH: 1, TM: 16},
+		},
+		{
+			iso8601.Duration{TM: 75, TS: 10}, iso8601.UnitSecond,
+			iso8601.Duration{TH: 1, TM: 15, TS: 10}, iso8601.Duration{TH: 1, TM: 15, TS: 10},
+		},
+		{
+			iso8601.Duration{TS: 90}, iso8601.UnitSecond,
+			iso8601.Duration{TM: 1, TS: 30}, iso8601.Duration{TM: 1, TS: 30},
+		},
+		{
+			iso8601.Duration{TM: 90}, iso8601.UnitMinute,
+			iso8601.Duration{TH: 1, TM: 30}, iso8601.Duration{TH: 1, TM: 30},
+		},
+		{
+			iso8601.Duration{Y: 1, M: 14}, iso8601.UnitMonth,
+			iso8601.Duration{Y: 2, M: 2}, iso8601.Duration{Y: 2, M: 2},
+		},
+		{
+			iso8601.Duration{TH: 30}, iso8601.UnitHour,
+			iso8601.Duration{TH: 30}, iso8601.Duration{TH: 30},
+		},
+		// Mixed signs are rounded as the value they sum to, e.g. PT2H-1M is
+		// PT1H59M.
+		{
+			iso8601.Duration{TH: 2, TM: -1}, iso8601.UnitHour,
+			iso8601.Duration{TH: 1}, iso8601.Duration{TH: 2},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: -30}, iso8601.UnitHour,
+			iso8601.Duration{}, iso8601.Duration{TH: 1},
+		},
+		{
+			iso8601.Duration{TH: -2, TM: 1}, iso8601.UnitHour,
+			iso8601.Duration{TH: -1}, iso8601.Duration{TH: -2},
+		},
+		{
+			iso8601.Duration{D: 1, TH: -1}, iso8601.UnitDay,
+			iso8601.Duration{}, iso8601.Duration{D: 1},
+		},
+		{
+			iso8601.Duration{TM: 1, TS: -0.5}, iso8601.UnitSecond,
+			iso8601.Duration{TS: 59}, iso8601.Duration{TM: 1},
+		},
+		{
+			iso8601.Duration{TH: 1, TM: -1}, iso8601.UnitMinute,
+			iso8601.Duration{TM: 59}, iso8601.Duration{TM: 59},
+		},
+		{
+			iso8601.Duration{Y: 1, M: -2, D: 20}, iso8601.UnitMonth,
+			iso8601.Duration{M: 10}, iso8601.Duration{M: 11},
+		},
+	}
+	for _, c := range cases {
+		if got := c.d.Truncate(c.unit); !got.Equal(c.truncate) {
+			t.Fatalf("Truncate(%s, %d): want=%s, got=%s", c.d, c.unit, c.truncate, got)
+		}
+		if got := c.d.Round(c.unit); !got.Equal(c.round) {
+			t.Fatalf("Round(%s, %d): want=%s, got=%s", c.d, c.unit, c.round, got)
+		}
+	}
+}
+
+func TestCanRoundAndTruncateAtReferenceDate(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	d := iso8601.Duration{M: 1, D: 15}
+	if got := d.RoundAt(iso8601.UnitMonth, jan); !got.Equal(iso8601.Duration{M: 2}) {
+		t.Fatalf("want=P2M, got=%s", got)
+	}
+	if got := d.RoundAt(iso8601.UnitMonth, feb); !got.Equal(iso8601.Duration{M: 1}) {
+		t.Fatalf("want=P1M, got=%s", got)
+	}
+	if got := (iso8601.Duration{D: 29}).TruncateAt(iso8601.UnitMonth, feb); !got.Equal(iso8601.Duration{M: 1}) {
+		t.Fatalf("want=P1M, got=%s", got)
+	}
+	if got := (iso8601.Duration{D: 29}).TruncateAt(iso8601.UnitMonth, jan); !got.Equal(iso8601.Duration{}) {
+		t.Fatalf("want=PT0S, got=%s", got)
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	// 2024-11-03 is 25 hours long, and 2024-03-10 23 hours.
+	fallBack := time.Date(2024, 11, 3, 0, 0, 0, 0, loc)
+	springForward := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	day := iso8601.Duration{TH: 24}
+	if got := day.TruncateAt(iso8601.UnitDay, fallBack); !got.Equal(iso8601.Duration{}) {
+		t.Fatalf("want=PT0S, got=%s", got)
+	}
+	if got := day.TruncateAt(iso8601.UnitDay, springForward); !got.Equal(iso8601.Duration{D: 1}) {
+		t.Fatalf("want=P1D, got=%s", got)
+	}
+	if got := day.RoundAt(iso8601.UnitDay, fallBack); !got.Equal(iso8601.Duration{D: 1}) {
+		t.Fatalf("want=P1D, got=%s", got)
+	}
+
+	// Mixed signs are measured as a whole, so P1DT-1H is 23 hours.
+	if got := (iso8601.Duration{D: 1, TH: -1}).TruncateAt(iso8601.UnitDay, jan); !got.Equal(iso8601.Duration{}) {
+		t.Fatalf("want=P0D, got=%s", got)
+	}
+	if got := (iso8601.Duration{D: 1, TH: -1}).RoundAt(iso8601.UnitDay, jan); !got.Equal(iso8601.Duration{D: 1}) {
+		t.Fatalf("want=P1D, got=%s", got)
+	}
+
+	// Time units need no reference.
+	got := iso8601.Duration{TM: 90, TS: 40}.RoundAt(iso8601.UnitMinute, jan)
+	if !got.Equal(iso8601.Duration{TH: 1, TM: 31}) {
+		t.Fatalf("want=PT1H31M, got=%s", got)
+	}
+}
@@ -0,0 +1,46 @@
+package iso8601
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// Buckets returns an iterator over the sub-intervals of i that fall between
+// successive boundaries anchor + n*d, for any integer n, in order. The first
+// and last buckets are clipped to i.
+//
+// Boundaries are computed as by d.Multiply(n).Shift(anchor), so months and
+// DST changes are handled as by Shift: P1M buckets anchored at a month start
+// are calendar months, and P1D buckets anchored at local midnight start at
+// local midnight even across DST changes. The anchor may be before, in or
+// after i; its location determines the wall clock used.
+//
+// If d does not move the anchor forward, or mixes signs and has a date
+// part, such as P1M-30D, whose boundaries need not be in order, Buckets
+// yields nothing.
+func (i Interval) Buckets(d Duration, anchor time.Time) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		if i.IsEmpty() {
+			return
+		}
+		n, ok := d.boundaryIndex(anchor, i.Start)
+		if !ok {
+			return
+		}
+
+		for start := d.shiftMultiple(anchor, n); start.Before(i.End); n++ {
+			end := d.shiftMultiple(anchor, n+1)
+			bucket := Interval{Start: laterOf(start, i.Start), End: earlierOf(end, i.End)}
+			if !yield(bucket) {
+				return
+			}
+			start = end
+		}
+	}
+}
+
+// Split returns the sub-intervals yielded by Buckets as a slice.
+func (i Interval) Split(d Duration, anchor time.Time) []Interval {
+	return slices.Collect(i.Buckets(d, anchor))
+}
@@ -0,0 +1,138 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanSplitIntoMonths(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	r := iso8601.Interval{Start: date(1, 15), End: date(4, 10)}
+
+	got := r.Split(iso8601.Duration{M: 1}, date(1, 1))
+	want := []iso8601.Interval{
+		{Start: date(1, 15), End: date(2, 1)},
+		{Start: date(2, 1), End: date(3, 1)},
+		{Start: date(3, 1), End: date(4, 1)},
+		{Start: date(4, 1), End: date(4, 10)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want=%v, got=%v", want, got)
+	}
+	for k := range want {
+		if !got[k].Start.Equal(want[k].Start) || !got[k].End.Equal(want[k].End) {
+			t.Fatalf("Bucket %d: want=%s, got=%s", k, want[k], got[k])
+		}
+	}
+
+	// An anchor far from the range gives the same calendar months.
+	far := r.Split(iso8601.Duration{M: 1}, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := r.Split(iso8601.Duration{M: 1}, time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC))
+	for k := range want {
+		if !far[k].Start.Equal(want[k].Start) || !later[k].Start.Equal(want[k].Start) {
+			t.Fatalf("Bucket %d: want=%s, got=%s and %s", k, want[k], far[k], later[k])
+		}
+	}
+}
+
+func TestCanSplitAlignedToHour(t *testing.T) {
+	r := iso8601.Interval{
+		Start: time.Date(2024, 3, 1, 9, 7, 0, 0, time.UTC),
+		End:   time.Date(2024, 3, 1, 9, 50, 0, 0, time.UTC),
+	}
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var starts []string
+	for b := range r.Buckets(iso8601.Duration{TM: 15}, anchor) {
+		starts = append(starts, b.Start.Format("15:04")+"-"+b.End.Format("15:04"))
+	}
+	want := []string{"09:07-09:15", "09:15-09:30", "09:30-09:45", "09:45-09:50"}
+	if len(starts) != len(want) {
+		t.Fatalf("want=%v, got=%v", want, starts)
+	}
+	for k := range want {
+		if starts[k] != want[k] {
+			t.Fatalf("want=%v, got=%v", want, starts)
+		}
+	}
+}
+
+func TestCanSplitDaysThroughDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := iso8601.Interval{
+		Start: time.Date(2024, 3, 9, 0, 0, 0, 0, loc),
+		End:   time.Date(2024, 3, 12, 0, 0, 0, 0, loc),
+	}
+
+	got := r.Split(iso8601.Duration{D: 1}, r.Start)
+	if len(got) != 3 {
+		t.Fatalf("want 3 buckets, got=%v", got)
+	}
+	for k, b := range got {
+		if b.Start.In(loc).Hour() != 0 {
+			t.Fatalf("Bucket %d: want local midnight, got=%s", k, b.Start.In(loc))
+		}
+	}
+	if got[1].End.Sub(got[1].Start) != 23*time.Hour {
+		t.Fatalf("want a 23h day, got=%s", got[1].End.Sub(got[1].Start))
+	}
+}
+
+func TestSplitStopsEarly(t *testing.T) {
+	r := iso8601.Interval{Start: at(0), End: at(12)}
+	count := 0
+	for range r.Buckets(iso8601.Duration{TH: 1}, at(0)) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Fatalf("want=3, got=%d", count)
+	}
+}
+
+func TestCanRejectNonPositiveSplit(t *testing.T) {
+	r := iso8601.Interval{Start: at(0), End: at(12)}
+	for _, d := range []iso8601.Duration{{}, {TH: -1}} {
+		if got := r.Split(d, at(0)); len(got) != 0 {
+			t.Fatalf("%s: want no buckets, got=%v", d, got)
+		}
+	}
+}
+
+func TestCanRejectMixedSignSplit(t *testing.T) {
+	anchor := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	r := iso8601.Interval{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// P1M-30D moves forward from January 31st to February 1st, but twice
+	// it is back to January 31st.
+	d := iso8601.Duration{M: 1, D: -30}
+	if got := r.Split(d, anchor); len(got) != 0 {
+		t.Fatalf("%s: want no buckets, got %d", d, len(got))
+	}
+	if at := r.Start.Add(time.Hour); !iso8601.FloorFrom(at, d, anchor).Equal(at) {
+		t.Fatalf("%s: want FloorFrom to return its input", d)
+	}
+
+	// Mixed signs in the time part alone are a fixed step.
+	got := r.Split(iso8601.Duration{TH: 1, TM: -30}, anchor)
+	if len(got) != 366*48 {
+		t.Fatalf("want %d buckets, got %d", 366*48, len(got))
+	}
+	for k := 1; k < len(got); k++ {
+		if got[k].IsEmpty() || !got[k].Start.Equal(got[k-1].End) {
+			t.Fatalf("Bucket %d: want contiguous non-empty buckets, got %s after %s", k, got[k], got[k-1])
+		}
+	}
+}
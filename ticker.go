@@ -0,0 +1,218 @@
+package iso8601
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxSleep bounds how long tickers and timers sleep before checking the
+// wall clock again, so that they notice clock jumps.
+const maxSleep = time.Minute
+
+// sleepUntil blocks until the wall clock reaches target, and reports whether
+// it did so before stop or ctx was closed. The wall clock is checked at
+// least every maxSleep, so a clock jump delays or advances the wake-up by at
+// most maxSleep. The pending timer is stopped if it returns early.
+func sleepUntil(c Clock, target time.Time, stop, ctx <-chan struct{}) bool {
+	for {
+		wait := target.Sub(c.Now())
+		if wait <= 0 {
+			return true
+		}
+		timer, cancel := c.NewTimer(min(wait, maxSleep))
+		select {
+		case <-timer:
+		case <-stop:
+			cancel()
+			return false
+		case <-ctx:
+			cancel()
+			return false
+		}
+	}
+}
+
+// Ticker delivers ticks at the calendar boundaries anchor + n*d, e.g. every
+// P1M at local midnight, which a time.Ticker cannot express.
+//
+// Boundaries are computed as by d.Multiply(n).Shift(anchor) in the anchor's
+// location, so ticks follow the wall clock across DST changes, and the wall
+// clock is re-read after every wait, so clock jumps do not cause drift. Like
+// time.Ticker, ticks are dropped for slow receivers, and a forward clock jump
+// past several boundaries ticks once, for the latest.
+type Ticker struct {
+	// C delivers the boundary time of each tick.
+	C <-chan time.Time
+
+	c     chan time.Time
+	clock Clock
+	ctx   context.Context
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewTicker returns a Ticker that ticks at each boundary anchor + n*d after
+// the current time. The anchor may be in the past. If d does not move the
+// anchor forward, or mixes signs and has a date part, the ticker never
+// ticks.
+func NewTicker(d Duration, anchor time.Time) *Ticker {
+	return NewTickerWithClock(context.Background(), RealClock{}, d, anchor)
+}
+
+// NewTickerContext is like NewTicker, but the ticker stops when ctx is done.
+func NewTickerContext(ctx context.Context, d Duration, anchor time.Time) *Ticker {
+	return NewTickerWithClock(ctx, RealClock{}, d, anchor)
+}
+
+// NewTickerWithClock is like NewTickerContext, but reads the time from c.
+func NewTickerWithClock(ctx context.Context, c Clock, d Duration, anchor time.Time) *Ticker {
+	ch := make(chan time.Time, 1)
+	t := &Ticker{C: ch, c: ch, clock: c, ctx: ctx}
+	t.start(d, anchor)
+	return t
+}
+
+func (t *Ticker) start(d Duration, anchor time.Time) {
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+	go t.run(d, anchor, t.stop, t.done)
+}
+
+func (t *Ticker) run(d Duration, anchor time.Time, stop, done chan struct{}) {
+	defer close(done)
+
+	n, ok := d.boundaryIndex(anchor, t.clock.Now())
+	if !ok {
+		return
+	}
+	for {
+		n++
+		next := d.shiftMultiple(anchor, n)
+		if !sleepUntil(t.clock, next, stop, t.ctx.Done()) {
+			return
+		}
+
+		// Skip to the latest boundary if the clock jumped past several, but
+		// never tick for the same boundary twice if it jumps backwards.
+		if latest, _ := d.boundaryIndex(anchor, t.clock.Now()); latest > n {
+			n = latest
+			next = d.shiftMultiple(anchor, n)
+		}
+		select {
+		case t.c <- next:
+		default:
+		}
+	}
+}
+
+// Stop turns off the ticker. No more ticks are sent after Stop returns, but
+// C is not closed.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.halt()
+}
+
+func (t *Ticker) halt() {
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+	<-t.done
+}
+
+// Reset stops the ticker and restarts it with the new duration and anchor.
+func (t *Ticker) Reset(d Duration, anchor time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.halt()
+	t.start(d, anchor)
+}
+
+// Timer delivers a single tick after a calendar duration, e.g. P1D from now,
+// which is 23 or 25 hours across a DST change. Like Ticker, it follows the
+// wall clock rather than elapsed time.
+type Timer struct {
+	// C delivers the time at which the timer was due.
+	C <-chan time.Time
+
+	c     chan time.Time
+	clock Clock
+
+	mu     sync.Mutex
+	stop   chan struct{}
+	done   chan struct{}
+	active bool
+	// fired is only written by run, and only read once done is closed.
+	fired bool
+}
+
+// NewTimer returns a Timer that ticks once at d.Shift(time.Now()).
+func NewTimer(d Duration) *Timer {
+	return NewTimerWithClock(RealClock{}, d)
+}
+
+// NewTimerWithClock is like NewTimer, but reads the time from c.
+func NewTimerWithClock(c Clock, d Duration) *Timer {
+	ch := make(chan time.Time, 1)
+	t := &Timer{C: ch, c: ch, clock: c}
+	t.start(d)
+	return t
+}
+
+func (t *Timer) start(d Duration) {
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+	t.active = true
+	t.fired = false
+	go t.run(d.Shift(t.clock.Now()), t.stop, t.done)
+}
+
+func (t *Timer) run(due time.Time, stop, done chan struct{}) {
+	defer close(done)
+	if !sleepUntil(t.clock, due, stop, nil) {
+		return
+	}
+	t.fired = true
+	select {
+	case t.c <- due:
+	default:
+	}
+}
+
+// Stop prevents the timer from firing. It reports whether the call stopped
+// the timer, i.e. false if it had already fired or been stopped.
+func (t *Timer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.halt()
+}
+
+// halt stops the timer and waits for it to finish, reporting whether it
+// was still active. t.mu must be held.
+func (t *Timer) halt() bool {
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+	<-t.done
+
+	wasActive := t.active && !t.fired
+	t.active = false
+	return wasActive
+}
+
+// Reset stops the timer and restarts it to tick at d.Shift from the current
+// time. It reports whether the timer had been active.
+func (t *Timer) Reset(d Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	wasActive := t.halt()
+	t.start(d)
+	return wasActive
+}
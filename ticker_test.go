@@ -0,0 +1,234 @@
+package iso8601_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func receive(t *testing.T, c <-chan time.Time) time.Time {
+	t.Helper()
+	select {
+	case got := <-c:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a tick")
+		return time.Time{}
+	}
+}
+
+func expectNoTick(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case got := <-c:
+		t.Fatalf("Unexpected tick at %s", got)
+	default:
+	}
+}
+
+func TestTickerFollowsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	midnight := func(day int) time.Time {
+		return time.Date(2024, 3, day, 0, 0, 0, 0, loc)
+	}
+	clock := iso8601.NewFakeClock(midnight(9).Add(12 * time.Hour))
+	ticker := iso8601.NewTickerWithClock(context.Background(), clock, iso8601.Duration{D: 1}, midnight(1))
+	defer ticker.Stop()
+
+	// DST starts on the 10th, so the day after is only 23 hours long.
+	for _, day := range []int{10, 11, 12} {
+		clock.BlockUntil(1)
+		clock.Set(midnight(day))
+		if got := receive(t, ticker.C); !got.Equal(midnight(day)) {
+			t.Fatalf("want=%s, got=%s", midnight(day), got)
+		}
+	}
+}
+
+func TestTickerWaitsUntilBoundary(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	anchor := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ticker := iso8601.NewTickerWithClock(context.Background(), clock, iso8601.Duration{M: 1}, anchor)
+	defer ticker.Stop()
+
+	// The ticker re-checks the wall clock regularly while waiting.
+	clock.BlockUntil(1)
+	clock.Set(time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC))
+	clock.BlockUntil(1)
+	expectNoTick(t, ticker.C)
+
+	clock.Set(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	if got, want := receive(t, ticker.C), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestTickerHandlesClockJumps(t *testing.T) {
+	hour := func(h int) time.Time {
+		return time.Date(2024, 5, 1, h, 0, 0, 0, time.UTC)
+	}
+	clock := iso8601.NewFakeClock(hour(0).Add(time.Minute))
+	ticker := iso8601.NewTickerWithClock(context.Background(), clock, iso8601.Duration{TH: 1}, hour(0))
+	defer ticker.Stop()
+
+	clock.BlockUntil(1)
+	clock.Set(hour(1))
+	if got := receive(t, ticker.C); !got.Equal(hour(1)) {
+		t.Fatalf("want=%s, got=%s", hour(1), got)
+	}
+
+	// A forward jump ticks once, for the latest boundary passed.
+	clock.BlockUntil(1)
+	clock.Set(hour(5).Add(30 * time.Minute))
+	if got := receive(t, ticker.C); !got.Equal(hour(5)) {
+		t.Fatalf("want=%s, got=%s", hour(5), got)
+	}
+	clock.BlockUntil(1)
+	clock.Set(hour(6))
+	if got := receive(t, ticker.C); !got.Equal(hour(6)) {
+		t.Fatalf("want=%s, got=%s", hour(6), got)
+	}
+
+	// A backward jump does not repeat boundaries already ticked.
+	clock.BlockUntil(1)
+	clock.Set(hour(3))
+	clock.BlockUntil(1)
+	clock.Set(hour(6))
+	clock.BlockUntil(1)
+	expectNoTick(t, ticker.C)
+	clock.Set(hour(7))
+	if got := receive(t, ticker.C); !got.Equal(hour(7)) {
+		t.Fatalf("want=%s, got=%s", hour(7), got)
+	}
+}
+
+func TestTickerStopAndReset(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	ticker := iso8601.NewTickerWithClock(context.Background(), clock, iso8601.Duration{D: 1}, start)
+
+	clock.BlockUntil(1)
+	ticker.Stop()
+	if got := clock.Pending(); got != 0 {
+		t.Fatalf("want no pending timers after Stop, got=%d", got)
+	}
+	clock.Set(start.AddDate(0, 0, 3))
+	expectNoTick(t, ticker.C)
+
+	ticker.Reset(iso8601.Duration{W: 1}, start)
+	defer ticker.Stop()
+	clock.BlockUntil(1)
+	clock.Set(start.AddDate(0, 0, 6))
+	clock.BlockUntil(1)
+	ticker.Reset(iso8601.Duration{W: 1}, start)
+	clock.BlockUntil(1)
+	if got := clock.Pending(); got != 1 {
+		t.Fatalf("want one pending timer after Reset, got=%d", got)
+	}
+	clock.Set(start.AddDate(0, 0, 7))
+	if got, want := receive(t, ticker.C), start.AddDate(0, 0, 7); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestTickerStopsWithContext(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := iso8601.NewTickerWithClock(ctx, clock, iso8601.Duration{D: 1}, start)
+	defer ticker.Stop()
+
+	clock.BlockUntil(1)
+	cancel()
+	ticker.Stop() // waits for the ticker to notice the cancellation
+	clock.Set(start.AddDate(0, 0, 3))
+	expectNoTick(t, ticker.C)
+}
+
+func TestTickerWithoutForwardDurationNeverTicks(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	ticker := iso8601.NewTickerWithClock(context.Background(), clock, iso8601.Duration{}, start)
+	ticker.Stop()
+	expectNoTick(t, ticker.C)
+}
+
+func TestTimerFiresAfterCalendarDuration(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	// DST starts on 2024-03-31, so P1D from noon the day before is 23 hours.
+	start := time.Date(2024, 3, 30, 12, 0, 0, 0, loc)
+	clock := iso8601.NewFakeClock(start)
+	timer := iso8601.NewTimerWithClock(clock, iso8601.Duration{D: 1})
+
+	clock.BlockUntil(1)
+	clock.Set(start.Add(23*time.Hour - time.Second))
+	clock.BlockUntil(1)
+	expectNoTick(t, timer.C)
+
+	clock.Set(start.Add(23 * time.Hour))
+	if got, want := receive(t, timer.C), time.Date(2024, 3, 31, 12, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	if timer.Stop() {
+		t.Fatal("Stop after firing should report false")
+	}
+}
+
+func TestTimerStopAndReset(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	timer := iso8601.NewTimerWithClock(clock, iso8601.Duration{TH: 1})
+
+	clock.BlockUntil(1)
+	if !timer.Stop() {
+		t.Fatal("Stop before firing should report true")
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Fatalf("want no pending timers after Stop, got=%d", got)
+	}
+	clock.Set(start.Add(2 * time.Hour))
+	expectNoTick(t, timer.C)
+
+	if timer.Reset(iso8601.Duration{TM: 30}) {
+		t.Fatal("Reset of a stopped timer should report false")
+	}
+	clock.BlockUntil(1)
+	clock.Set(start.Add(2*time.Hour + 30*time.Minute))
+	if got, want := receive(t, timer.C), start.Add(2*time.Hour+30*time.Minute); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+}
+
+func TestTimerConcurrentStopAndReset(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	clock := iso8601.NewFakeClock(start)
+	timer := iso8601.NewTimerWithClock(clock, iso8601.Duration{TH: 1})
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			timer.Reset(iso8601.Duration{TH: 1})
+			timer.Stop()
+		}()
+	}
+	wg.Wait()
+
+	timer.Stop()
+	if got := clock.Pending(); got != 0 {
+		t.Fatalf("want no pending timers after Stop, got=%d", got)
+	}
+	clock.Set(start.Add(2 * time.Hour))
+	expectNoTick(t, timer.C)
+}
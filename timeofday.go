@@ -0,0 +1,182 @@
+package iso8601
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// oneDay is the length of a day without DST changes.
+const oneDay = 24 * time.Hour
+
+var timeOfDayPattern = regexp.MustCompile(`^T?` + timeExpr + `$`)
+
+// TimeOfDay represents a time of day, without a date or a location.
+//
+// Hour may be 24, with all other fields zero, to represent the end of the
+// day as ISO8601 allows.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the time of day of t, in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second, Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses an ISO8601 time of day in the extended (09:30:15)
+// or basic (093015) format, optionally prefixed with T. Reduced precision
+// (09:30, 09), a decimal fraction on the least significant component
+// (09.5 is 09:30) and 24:00 are accepted.
+func ParseTimeOfDay(from string) (TimeOfDay, error) {
+	parts := submatches(timeOfDayPattern, from)
+	if parts == nil {
+		return TimeOfDay{}, errors.New("could not parse time string")
+	}
+
+	clock, err := resolveClock(parts)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	if clock == oneDay {
+		return TimeOfDay{Hour: 24}, nil
+	}
+	return timeOfDayAt(clock), nil
+}
+
+// timeOfDayAt returns the time of day at the given time since midnight,
+// which must be in [0, 24h).
+func timeOfDayAt(clock time.Duration) TimeOfDay {
+	return TimeOfDay{
+		Hour:       int(clock / time.Hour),
+		Minute:     int(clock % time.Hour / time.Minute),
+		Second:     int(clock % time.Minute / time.Second),
+		Nanosecond: int(clock % time.Second),
+	}
+}
+
+// sinceMidnight returns the time elapsed since midnight at t.
+func (t TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// IsValid reports whether t is a valid time of day, from 00:00 to 24:00.
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour == 24 {
+		return t.Minute == 0 && t.Second == 0 && t.Nanosecond == 0
+	}
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// Compare returns -1 if t is before other, +1 if it is after, and 0 if
+// they are the same time of day.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	return cmp.Compare(t.sinceMidnight(), other.sinceMidnight())
+}
+
+// Before reports whether t is before other.
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.Compare(other) < 0
+}
+
+// After reports whether t is after other.
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return t.Compare(other) > 0
+}
+
+// On returns the time at t on the given date in the given location. 24:00
+// is midnight at the start of the next day.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// Shift returns the time of day shifted by the time part of d, wrapping
+// around midnight, and the number of days carried, e.g. 23:00 + PT2H is
+// 01:00 with a carry of 1. The date part of d is ignored.
+//
+// A result of exactly midnight is 00:00, never 24:00.
+func (t TimeOfDay) Shift(d Duration) (TimeOfDay, int) {
+	return t.add(d.timeDuration())
+}
+
+// Unshift returns the time of day shifted back by the time part of d,
+// wrapping around midnight, and the number of days carried, which is
+// negative when the result falls on an earlier day, e.g. 01:00 - PT2H is
+// 23:00 with a carry of -1. The date part of d is ignored.
+func (t TimeOfDay) Unshift(d Duration) (TimeOfDay, int) {
+	return t.add(-d.timeDuration())
+}
+
+func (t TimeOfDay) add(td time.Duration) (TimeOfDay, int) {
+	clock := t.sinceMidnight() + td
+	days := int(clock / oneDay)
+	clock %= oneDay
+	if clock < 0 {
+		clock += oneDay
+		days--
+	}
+	return timeOfDayAt(clock), days
+}
+
+// BetweenTimes returns the time-only Duration from one time of day to
+// another, such that from.Shift(BetweenTimes(from, to)) is to on the same
+// day. The result is negative if to is before from.
+func BetweenTimes(from, to TimeOfDay) Duration {
+	return FromTimeDuration(to.sinceMidnight() - from.sinceMidnight())
+}
+
+// String returns the extended representation of t, e.g. 09:30:00, with any
+// fractional seconds in their shortest form.
+func (t TimeOfDay) String() string {
+	var buf [32]byte
+	return string(t.AppendFormat(buf[:0]))
+}
+
+// AppendFormat appends the extended representation of t, as returned by
+// String, to dst and returns the extended buffer.
+func (t TimeOfDay) AppendFormat(dst []byte) []byte {
+	dst = appendDigits(dst, t.Hour, 2)
+	dst = append(dst, ':')
+	dst = appendDigits(dst, t.Minute, 2)
+	dst = append(dst, ':')
+	dst = appendDigits(dst, t.Second, 2)
+	if t.Nanosecond == 0 {
+		return dst
+	}
+
+	dst = append(dst, '.')
+	dst = appendDigits(dst, t.Nanosecond, 9)
+	for dst[len(dst)-1] == '0' {
+		dst = dst[:len(dst)-1]
+	}
+	return dst
+}
+
+// MarshalJSON satisfies json.Marshaler.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler.
+func (t *TimeOfDay) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	tmp, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	*t = tmp
+
+	return nil
+}
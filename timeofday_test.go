@@ -0,0 +1,156 @@
+package iso8601_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanParseTimeOfDay(t *testing.T) {
+	cases := []struct {
+		from string
+		want iso8601.TimeOfDay
+	}{
+		{"09:30", iso8601.TimeOfDay{Hour: 9, Minute: 30}},
+		{"0930", iso8601.TimeOfDay{Hour: 9, Minute: 30}},
+		{"T09:30:15", iso8601.TimeOfDay{Hour: 9, Minute: 30, Second: 15}},
+		{"09:30:15.25", iso8601.TimeOfDay{Hour: 9, Minute: 30, Second: 15, Nanosecond: 250000000}},
+		{"09.5", iso8601.TimeOfDay{Hour: 9, Minute: 30}},
+		{"09", iso8601.TimeOfDay{Hour: 9}},
+		{"24:00", iso8601.TimeOfDay{Hour: 24}},
+		{"24:00:00", iso8601.TimeOfDay{Hour: 24}},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseTimeOfDay(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+
+	for _, bad := range []string{"", "9:30", "25:00", "24:30", "09:60", "09:30Z"} {
+		if _, err := iso8601.ParseTimeOfDay(bad); err == nil {
+			t.Fatalf("%s: Expected error, got none", bad)
+		}
+	}
+}
+
+func TestCanStringifyTimeOfDay(t *testing.T) {
+	cases := []struct {
+		t    iso8601.TimeOfDay
+		want string
+	}{
+		{iso8601.TimeOfDay{Hour: 9, Minute: 30}, "09:30:00"},
+		{iso8601.TimeOfDay{Hour: 24}, "24:00:00"},
+		{iso8601.TimeOfDay{Hour: 23, Minute: 59, Second: 59, Nanosecond: 500000000}, "23:59:59.5"},
+	}
+
+	for k, c := range cases {
+		if got := c.t.String(); got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanShiftTimeOfDay(t *testing.T) {
+	cases := []struct {
+		from      string
+		duration  iso8601.Duration
+		want      string
+		wantCarry int
+	}{
+		{"09:30", iso8601.Duration{TM: 45}, "10:15:00", 0},
+		{"23:00", iso8601.Duration{TH: 2}, "01:00:00", 1},
+		{"23:00", iso8601.Duration{TH: 1}, "00:00:00", 1},
+		{"24:00", iso8601.Duration{TM: 30}, "00:30:00", 1},
+		{"09:30", iso8601.Duration{TH: 49}, "10:30:00", 2},
+		{"09:30", iso8601.Duration{D: 3, TS: 1.5}, "09:30:01.5", 0},
+		{"01:00", iso8601.Duration{TH: -2}, "23:00:00", -1},
+	}
+
+	for k, c := range cases {
+		from, err := iso8601.ParseTimeOfDay(c.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, carry := from.Shift(c.duration)
+		if got.String() != c.want || carry != c.wantCarry {
+			t.Fatalf("Case %d: want=%s+%d, got=%s+%d", k, c.want, c.wantCarry, got, carry)
+		}
+		// 24:00 comes back as 00:00 of the next day, so it does not round trip.
+		if from.Hour == 24 {
+			continue
+		}
+		back, backCarry := got.Unshift(c.duration)
+		if back != from || backCarry != -carry {
+			t.Fatalf("Case %d: Unshift: want=%s+%d, got=%s+%d", k, from, -carry, back, backCarry)
+		}
+	}
+}
+
+func TestCanGetDurationBetweenTimes(t *testing.T) {
+	cases := []struct {
+		from string
+		to   string
+		want iso8601.Duration
+	}{
+		{"09:30", "17:00", iso8601.Duration{TH: 7, TM: 30}},
+		{"17:00", "09:30", iso8601.Duration{TH: -7, TM: -30}},
+		{"00:00", "24:00", iso8601.Duration{TH: 24}},
+		{"09:30:00", "09:30:00.5", iso8601.Duration{TS: 0.5}},
+	}
+
+	for k, c := range cases {
+		from, err := iso8601.ParseTimeOfDay(c.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		to, err := iso8601.ParseTimeOfDay(c.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := iso8601.BetweenTimes(from, to); !got.Equal(c.want) {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanPlaceTimeOfDayOnDate(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := iso8601.Date{Year: 2024, Month: time.March, Day: 10}
+
+	got := iso8601.TimeOfDay{Hour: 9, Minute: 30}.On(date, loc)
+	if want := time.Date(2024, 3, 10, 9, 30, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	got = iso8601.TimeOfDay{Hour: 24}.On(date, loc)
+	if want := time.Date(2024, 3, 11, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("want=%s, got=%s", want, got)
+	}
+	if tod := iso8601.TimeOfDayOf(got); tod != (iso8601.TimeOfDay{}) {
+		t.Fatalf("want=00:00:00, got=%s", tod)
+	}
+}
+
+func TestCanMarshalTimeOfDayJSON(t *testing.T) {
+	want := iso8601.TimeOfDay{Hour: 9, Minute: 30}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got iso8601.TimeOfDay
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("want=%s, got=%s via %s", want, got, b)
+	}
+}
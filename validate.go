@@ -0,0 +1,195 @@
+package iso8601
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Errors wrapped by the violations of the built-in rules, for use with
+// errors.Is.
+var (
+	ErrTooShort          = errors.New("duration too short")
+	ErrTooLong           = errors.New("duration too long")
+	ErrMonthsOrYears     = errors.New("duration has months or years")
+	ErrFractionalSeconds = errors.New("duration has fractional seconds")
+	ErrWeeksWithDays     = errors.New("duration mixes weeks with days")
+)
+
+// Validator is implemented by anything that validates a Duration, such as
+// Constraints. Struct-tag validation libraries can call it from a custom
+// validation function.
+type Validator interface {
+	// Validate returns nil if d is valid, or a *ValidationError listing the
+	// violations otherwise.
+	Validate(d Duration) error
+}
+
+// Rule is a single constraint on a Duration.
+type Rule interface {
+	// Check returns an error describing how d violates the rule, or nil.
+	Check(d Duration) error
+}
+
+// RuleFunc adapts a function to a Rule.
+type RuleFunc func(d Duration) error
+
+// Check returns f(d).
+func (f RuleFunc) Check(d Duration) error {
+	return f(d)
+}
+
+// AtLeast returns a Rule that d is no shorter than shortest, measured from
+// r as by Reference.Compare. Violations wrap ErrTooShort.
+func AtLeast(shortest Duration, r Reference) Rule {
+	return RuleFunc(func(d Duration) error {
+		if r.Compare(d, shortest) < 0 {
+			return fmt.Errorf("%w: %s is shorter than %s", ErrTooShort, d, shortest)
+		}
+		return nil
+	})
+}
+
+// AtMost returns a Rule that d is no longer than longest, measured from r
+// as by Reference.Compare. Violations wrap ErrTooLong.
+func AtMost(longest Duration, r Reference) Rule {
+	return RuleFunc(func(d Duration) error {
+		if r.Compare(d, longest) > 0 {
+			return fmt.Errorf("%w: %s is longer than %s", ErrTooLong, d, longest)
+		}
+		return nil
+	})
+}
+
+// InRange returns a Rule that d is from shortest to longest inclusive,
+// measured from r as by Reference.Compare.
+func InRange(shortest, longest Duration, r Reference) Rule {
+	return Constraints{AtLeast(shortest, r), AtMost(longest, r)}
+}
+
+// NoMonthsOrYears is a Rule that d has no months or years, whose lengths
+// vary. Violations wrap ErrMonthsOrYears.
+var NoMonthsOrYears Rule = RuleFunc(func(d Duration) error {
+	if d.Y != 0 || d.M != 0 {
+		return fmt.Errorf("%w: %s", ErrMonthsOrYears, d)
+	}
+	return nil
+})
+
+// WholeSeconds is a Rule that d has no fractional seconds. Violations wrap
+// ErrFractionalSeconds.
+var WholeSeconds Rule = RuleFunc(func(d Duration) error {
+	if d.TS != math.Trunc(d.TS) {
+		return fmt.Errorf("%w: %s", ErrFractionalSeconds, d)
+	}
+	return nil
+})
+
+// NoWeeksWithDays is a Rule that d does not have both weeks and days, which
+// ISO8601 does not allow in a single duration. Violations wrap
+// ErrWeeksWithDays.
+var NoWeeksWithDays Rule = RuleFunc(func(d Duration) error {
+	if d.W != 0 && d.D != 0 {
+		return fmt.Errorf("%w: %s", ErrWeeksWithDays, d)
+	}
+	return nil
+})
+
+// Constraints is a set of rules that a Duration must all satisfy. It is
+// itself a Rule, so sets of constraints can be composed.
+type Constraints []Rule
+
+// Validate checks d against every rule, and returns nil if all are
+// satisfied, or a *ValidationError listing every violation.
+func (c Constraints) Validate(d Duration) error {
+	var violations []error
+	for _, rule := range c {
+		err := rule.Check(d)
+		var nested *ValidationError
+		switch {
+		case err == nil:
+		case errors.As(err, &nested):
+			violations = append(violations, nested.Violations...)
+		default:
+			violations = append(violations, err)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Duration: d, Violations: violations}
+}
+
+// Check satisfies Rule, returning the same as Validate.
+func (c Constraints) Check(d Duration) error {
+	return c.Validate(d)
+}
+
+// ValidationError lists the rules a Duration violates.
+type ValidationError struct {
+	Duration   Duration
+	Violations []error
+}
+
+// Error returns the violations, separated by semicolons.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid duration ")
+	b.WriteString(e.Duration.String())
+	for k, v := range e.Violations {
+		if k == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the violations, so that errors.Is matches the errors they
+// wrap, e.g. ErrTooShort.
+func (e *ValidationError) Unwrap() []error {
+	return e.Violations
+}
+
+// ParseConstraints parses constraints from a comma-separated list, as found
+// in struct tags, e.g. "min=PT5M,max=P30D,wholeseconds". The supported
+// constraints are:
+//
+//	min=<duration>    AtLeast, with average calendar assumptions
+//	max=<duration>    AtMost, with average calendar assumptions
+//	nomonthsoryears   NoMonthsOrYears
+//	wholeseconds      WholeSeconds
+//	noweekswithdays   NoWeeksWithDays
+func ParseConstraints(from string) (Constraints, error) {
+	var c Constraints
+	if strings.TrimSpace(from) == "" {
+		return c, nil
+	}
+	for _, item := range strings.Split(from, ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(item), "=")
+		switch {
+		case (name == "min" || name == "max") && hasValue:
+			d, err := ParseISO8601(value)
+			if err != nil {
+				return nil, fmt.Errorf("constraint %s: %w", name, err)
+			}
+			if name == "min" {
+				c = append(c, AtLeast(d, Reference{}))
+			} else {
+				c = append(c, AtMost(d, Reference{}))
+			}
+		case name == "nomonthsoryears" && !hasValue:
+			c = append(c, NoMonthsOrYears)
+		case name == "wholeseconds" && !hasValue:
+			c = append(c, WholeSeconds)
+		case name == "noweekswithdays" && !hasValue:
+			c = append(c, NoWeeksWithDays)
+		default:
+			return nil, fmt.Errorf("unknown duration constraint %q", item)
+		}
+	}
+	return c, nil
+}
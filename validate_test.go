@@ -0,0 +1,115 @@
+package iso8601_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+var _ iso8601.Validator = iso8601.Constraints{}
+
+func TestCanValidateDurations(t *testing.T) {
+	c := iso8601.Constraints{
+		iso8601.InRange(iso8601.Duration{TM: 5}, iso8601.Duration{D: 30}, iso8601.Reference{}),
+		iso8601.NoMonthsOrYears,
+		iso8601.WholeSeconds,
+		iso8601.NoWeeksWithDays,
+	}
+	cases := []struct {
+		d    iso8601.Duration
+		want []error
+	}{
+		{iso8601.Duration{TH: 1}, nil},
+		{iso8601.Duration{TM: 5}, nil},
+		{iso8601.Duration{D: 30}, nil},
+		{iso8601.Duration{TM: 4, TS: 59}, []error{iso8601.ErrTooShort}},
+		{iso8601.Duration{D: 30, TS: 1}, []error{iso8601.ErrTooLong}},
+		{iso8601.Duration{M: 1}, []error{iso8601.ErrTooLong, iso8601.ErrMonthsOrYears}},
+		{iso8601.Duration{TM: 10, TS: 0.5}, []error{iso8601.ErrFractionalSeconds}},
+		{iso8601.Duration{W: 1, D: 1}, []error{iso8601.ErrWeeksWithDays}},
+		{iso8601.Duration{TS: 1.5}, []error{iso8601.ErrTooShort, iso8601.ErrFractionalSeconds}},
+	}
+	for _, c2 := range cases {
+		err := c.Validate(c2.d)
+		if c2.want == nil {
+			if err != nil {
+				t.Fatalf("%s: want valid, got %v", c2.d, err)
+			}
+			continue
+		}
+
+		var verr *iso8601.ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("%s: want *ValidationError, got %v", c2.d, err)
+		}
+		if len(verr.Violations) != len(c2.want) {
+			t.Fatalf("%s: want %d violations, got %v", c2.d, len(c2.want), err)
+		}
+		for _, want := range c2.want {
+			if !errors.Is(err, want) {
+				t.Fatalf("%s: want %v, got %v", c2.d, want, err)
+			}
+		}
+	}
+}
+
+func TestValidationErrorIsDetailed(t *testing.T) {
+	c := iso8601.Constraints{iso8601.AtLeast(iso8601.Duration{TM: 5}, iso8601.Reference{}), iso8601.WholeSeconds}
+	err := c.Validate(iso8601.Duration{TS: 1.5})
+	want := "invalid duration PT1.5S: duration too short: PT1.5S is shorter than PT5M; " +
+		"duration has fractional seconds: PT1.5S"
+	if err == nil || err.Error() != want {
+		t.Fatalf("want=%q, got=%v", want, err)
+	}
+}
+
+func TestCanComposeRules(t *testing.T) {
+	timeOnly := iso8601.Constraints{
+		iso8601.NoMonthsOrYears,
+		iso8601.RuleFunc(func(d iso8601.Duration) error {
+			if d.W != 0 || d.D != 0 {
+				return errors.New("has days")
+			}
+			return nil
+		}),
+	}
+	c := iso8601.Constraints{timeOnly, iso8601.WholeSeconds}
+
+	err := c.Validate(iso8601.Duration{M: 1, D: 1, TS: 0.5})
+	var verr *iso8601.ValidationError
+	if !errors.As(err, &verr) || len(verr.Violations) != 3 {
+		t.Fatalf("want 3 flattened violations, got %v", err)
+	}
+	if err := c.Validate(iso8601.Duration{TH: 2}); err != nil {
+		t.Fatalf("want valid, got %v", err)
+	}
+}
+
+func TestCanParseConstraints(t *testing.T) {
+	c, err := iso8601.ParseConstraints("min=PT5M, max=P30D,nomonthsoryears,wholeseconds,noweekswithdays")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 5 {
+		t.Fatalf("want 5 rules, got %d", len(c))
+	}
+	if err := c.Validate(iso8601.Duration{TH: 1}); err != nil {
+		t.Fatalf("want valid, got %v", err)
+	}
+	if err := c.Validate(iso8601.Duration{TM: 1}); !errors.Is(err, iso8601.ErrTooShort) {
+		t.Fatalf("want ErrTooShort, got %v", err)
+	}
+
+	if c, err := iso8601.ParseConstraints(""); err != nil || len(c) != 0 {
+		t.Fatalf("want no rules, got %v %v", c, err)
+	}
+	for _, bad := range []string{"min=5m", "unknown", "wholeseconds=1", "max"} {
+		if _, err := iso8601.ParseConstraints(bad); err == nil {
+			t.Fatalf("%q: want error", bad)
+		} else if !strings.Contains(err.Error(), "constraint") && !strings.Contains(err.Error(), "parse") {
+			t.Fatalf("%q: unhelpful error %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package iso8601
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	weekDatePattern    = regexp.MustCompile(`^` + yearExpr + weekDateExpr + `$`)
+	ordinalDatePattern = regexp.MustCompile(`^` + yearExpr + ordinalDateExpr + `$`)
+)
+
+// WeekDate represents an ISO8601 week date, e.g. 2024-W09-5.
+//
+// Year is the ISO week-numbering year, which differs from the calendar year
+// for days around the new year: 2024-12-30 is 2025-W01-1.
+type WeekDate struct {
+	Year    int
+	Week    int
+	Weekday time.Weekday
+}
+
+// WeekDateOf returns the week date of t.
+func WeekDateOf(t time.Time) WeekDate {
+	year, week := t.ISOWeek()
+	return WeekDate{Year: year, Week: week, Weekday: t.Weekday()}
+}
+
+// ParseWeekDate parses an ISO8601 week date in the extended (2024-W09-5) or
+// basic (2024W095) format. If the weekday is omitted (2024-W09), the week's
+// Monday is returned.
+func ParseWeekDate(from string) (WeekDate, error) {
+	parts := submatches(weekDatePattern, from)
+	if parts == nil {
+		return WeekDate{}, errors.New("could not parse week date string")
+	}
+
+	year, month, day, _, err := resolveDate(parts)
+	if err != nil {
+		return WeekDate{}, err
+	}
+	return WeekDateOf(time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)), nil
+}
+
+// Time returns midnight at the start of w in the given location.
+func (w WeekDate) Time(loc *time.Location) time.Time {
+	// January 4th is always in week 1.
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := 1 - isoWeekday(jan4.Weekday())
+	return time.Date(w.Year, time.January, 4+monday+(w.Week-1)*7+isoWeekday(w.Weekday)-1, 0, 0, 0, 0, loc)
+}
+
+// Shift returns the week date shifted by the date part of d.
+//
+// Shift uses time.AddDate, like Duration.Shift, so a PnW duration moves w
+// by whole weeks and keeps its weekday, while years and months are applied
+// to the corresponding calendar date. The time part of d is ignored.
+func (w WeekDate) Shift(d Duration) WeekDate {
+	return WeekDateOf(w.Time(time.UTC).AddDate(d.Y, d.M, d.W*7+d.D))
+}
+
+// Unshift returns the week date shifted back by the date part of d.
+func (w WeekDate) Unshift(d Duration) WeekDate {
+	return WeekDateOf(w.Time(time.UTC).AddDate(-d.Y, -d.M, -(d.W*7 + d.D)))
+}
+
+// String returns the extended representation of w, e.g. 2024-W09-5.
+func (w WeekDate) String() string {
+	var buf [16]byte
+	return string(w.appendFormat(buf[:0], false, true))
+}
+
+func (w WeekDate) appendFormat(dst []byte, basic, withWeekday bool) []byte {
+	dst = appendYear(dst, w.Year)
+	if !basic {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, 'W')
+	dst = appendDigits(dst, w.Week, 2)
+	if !withWeekday {
+		return dst
+	}
+	if !basic {
+		dst = append(dst, '-')
+	}
+	return strconv.AppendInt(dst, int64(isoWeekday(w.Weekday)), 10)
+}
+
+// OrdinalDate represents an ISO8601 ordinal date, e.g. 2024-061, where Day
+// is the day of the year starting at 1.
+type OrdinalDate struct {
+	Year int
+	Day  int
+}
+
+// OrdinalDateOf returns the ordinal date of t.
+func OrdinalDateOf(t time.Time) OrdinalDate {
+	return OrdinalDate{Year: t.Year(), Day: t.YearDay()}
+}
+
+// ParseOrdinalDate parses an ISO8601 ordinal date in the extended
+// (2024-061) or basic (2024061) format.
+func ParseOrdinalDate(from string) (OrdinalDate, error) {
+	parts := submatches(ordinalDatePattern, from)
+	if parts == nil {
+		return OrdinalDate{}, errors.New("could not parse ordinal date string")
+	}
+
+	year, month, day, _, err := resolveDate(parts)
+	if err != nil {
+		return OrdinalDate{}, err
+	}
+	return OrdinalDateOf(time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)), nil
+}
+
+// Time returns midnight at the start of o in the given location.
+func (o OrdinalDate) Time(loc *time.Location) time.Time {
+	return time.Date(o.Year, time.January, o.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the extended representation of o, e.g. 2024-061.
+func (o OrdinalDate) String() string {
+	var buf [16]byte
+	return string(o.appendFormat(buf[:0], false))
+}
+
+func (o OrdinalDate) appendFormat(dst []byte, basic bool) []byte {
+	dst = appendYear(dst, o.Year)
+	if !basic {
+		dst = append(dst, '-')
+	}
+	return appendDigits(dst, o.Day, 3)
+}
+
+// isoWeekday returns the ISO8601 number of the weekday, from Monday=1 to
+// Sunday=7.
+func isoWeekday(d time.Weekday) int {
+	if d == time.Sunday {
+		return 7
+	}
+	return int(d)
+}
+
+// weeksIn returns the number of ISO weeks in the given week-numbering year.
+func weeksIn(year int) int {
+	// December 28th is always in the last week.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
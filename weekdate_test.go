@@ -0,0 +1,165 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func TestCanParseWeekDate(t *testing.T) {
+	cases := []struct {
+		from string
+		want iso8601.WeekDate
+		date string
+	}{
+		{"2024-W09-5", iso8601.WeekDate{Year: 2024, Week: 9, Weekday: time.Friday}, "2024-03-01"},
+		{"2024W095", iso8601.WeekDate{Year: 2024, Week: 9, Weekday: time.Friday}, "2024-03-01"},
+		{"2024-W09", iso8601.WeekDate{Year: 2024, Week: 9, Weekday: time.Monday}, "2024-02-26"},
+		{"2025-W01-1", iso8601.WeekDate{Year: 2025, Week: 1, Weekday: time.Monday}, "2024-12-30"},
+		{"2020-W53-7", iso8601.WeekDate{Year: 2020, Week: 53, Weekday: time.Sunday}, "2021-01-03"},
+		{"2021-W01-1", iso8601.WeekDate{Year: 2021, Week: 1, Weekday: time.Monday}, "2021-01-04"},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseWeekDate(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if got != c.want {
+			t.Fatalf("Case %d: want=%+v, got=%+v", k, c.want, got)
+		}
+		if date := got.Time(time.UTC).Format(time.DateOnly); date != c.date {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.date, date)
+		}
+		if tm, err := iso8601.ParseDateTime(c.from); err != nil || tm.Format(time.DateOnly) != c.date {
+			t.Fatalf("Case %d: ParseDateTime: want=%s, got=%s (%v)", k, c.date, tm, err)
+		}
+	}
+}
+
+func TestCanRejectBadWeekDate(t *testing.T) {
+	cases := []string{
+		"2024-W00-1",
+		"2024-W53-1",
+		"2024-W09-0",
+		"2024-W09-8",
+		"2024-09-5",
+		"2024-W9-5",
+	}
+
+	for _, c := range cases {
+		if _, err := iso8601.ParseWeekDate(c); err == nil {
+			t.Fatalf("%s: Expected error, got none", c)
+		}
+	}
+}
+
+func TestCanStringifyWeekDate(t *testing.T) {
+	cases := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-W09-5"},
+		{time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "2025-W01-1"},
+		{time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), "2020-W53-7"},
+	}
+
+	for k, c := range cases {
+		if got := iso8601.WeekDateOf(c.t).String(); got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+	}
+}
+
+func TestCanShiftWeekDate(t *testing.T) {
+	cases := []struct {
+		from     string
+		duration iso8601.Duration
+		want     string
+	}{
+		{"2024-W09-5", iso8601.Duration{W: 1}, "2024-W10-5"},
+		{"2024-W52-3", iso8601.Duration{W: 1}, "2025-W01-3"},
+		{"2020-W52-1", iso8601.Duration{W: 1}, "2020-W53-1"},
+		{"2024-W09-5", iso8601.Duration{D: 3}, "2024-W10-1"},
+		{"2024-W09-5", iso8601.Duration{W: 52}, "2025-W09-5"},
+		{"2024-W09-5", iso8601.Duration{W: 1, TH: 12}, "2024-W10-5"},
+	}
+
+	for k, c := range cases {
+		from, err := iso8601.ParseWeekDate(c.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := from.Shift(c.duration)
+		if got.String() != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		if back := got.Unshift(c.duration); back != from {
+			t.Fatalf("Case %d: Unshift: want=%s, got=%s", k, from, back)
+		}
+	}
+}
+
+func TestCanParseOrdinalDate(t *testing.T) {
+	cases := []struct {
+		from string
+		want iso8601.OrdinalDate
+		date string
+	}{
+		{"2024-061", iso8601.OrdinalDate{Year: 2024, Day: 61}, "2024-03-01"},
+		{"2023061", iso8601.OrdinalDate{Year: 2023, Day: 61}, "2023-03-02"},
+		{"2024-366", iso8601.OrdinalDate{Year: 2024, Day: 366}, "2024-12-31"},
+	}
+
+	for k, c := range cases {
+		got, err := iso8601.ParseOrdinalDate(c.from)
+		if err != nil {
+			t.Fatalf("Case %d: failed to parse %s: %v", k, c.from, err)
+		}
+		if got != c.want {
+			t.Fatalf("Case %d: want=%+v, got=%+v", k, c.want, got)
+		}
+		if date := got.Time(time.UTC).Format(time.DateOnly); date != c.date {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.date, date)
+		}
+		if want := c.date[:4] + "-" + c.from[len(c.from)-3:]; got.String() != want {
+			t.Fatalf("Case %d: String: want=%s, got=%s", k, want, got)
+		}
+	}
+
+	for _, bad := range []string{"2023-366", "2024-000", "2024-06"} {
+		if _, err := iso8601.ParseOrdinalDate(bad); err == nil {
+			t.Fatalf("%s: Expected error, got none", bad)
+		}
+	}
+}
+
+func TestCanFormatDateTimeAsWeekOrOrdinalDate(t *testing.T) {
+	ts := time.Date(2024, 12, 30, 12, 30, 0, 0, time.UTC)
+	cases := []struct {
+		opts iso8601.DateTimeOptions
+		want string
+	}{
+		{iso8601.DateTimeOptions{DateForm: iso8601.WeekDateForm}, "2025-W01-1T12:30:00Z"},
+		{iso8601.DateTimeOptions{DateForm: iso8601.WeekDateForm, Basic: true}, "2025W011T123000Z"},
+		{iso8601.DateTimeOptions{DateForm: iso8601.WeekDateForm, Resolution: iso8601.ResolutionMonth}, "2025-W01"},
+		{iso8601.DateTimeOptions{DateForm: iso8601.WeekDateForm, Resolution: iso8601.ResolutionYear}, "2025"},
+		{iso8601.DateTimeOptions{DateForm: iso8601.OrdinalDateForm, Resolution: iso8601.ResolutionDay}, "2024-365"},
+		{iso8601.DateTimeOptions{DateForm: iso8601.OrdinalDateForm, Basic: true}, "2024365T123000Z"},
+	}
+
+	for k, c := range cases {
+		got := iso8601.FormatDateTime(ts, c.opts)
+		if got != c.want {
+			t.Fatalf("Case %d: want=%s, got=%s", k, c.want, got)
+		}
+		if c.opts.Resolution != iso8601.ResolutionSecond {
+			continue
+		}
+		back, err := iso8601.ParseDateTime(got)
+		if err != nil || !back.Equal(ts) {
+			t.Fatalf("Case %d: want=%s, got=%s (%v)", k, ts, back, err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package iso8601
+
+import "time"
+
+// WorkingHours is a weekly schedule of open hours, e.g. 09:00 to 17:00 on
+// the working days of a BusinessCalendar, in a given location.
+type WorkingHours struct {
+	// Open and Close bound the open hours of every working day. Close may
+	// be 24:00.
+	Open, Close TimeOfDay
+	// Calendar defines the working days.
+	Calendar BusinessCalendar
+	// Location is the time zone of the open hours. If nil, the location of
+	// the time passed to each method is used.
+	Location *time.Location
+}
+
+// IsOpen reports whether t falls within open hours.
+func (w WorkingHours) IsOpen(t time.Time) bool {
+	t = t.In(w.location(t))
+	day := DateOf(t)
+	return w.Calendar.IsBusinessDay(day) && w.hoursOn(day, t.Location()).Contains(t)
+}
+
+// Shift returns t shifted by d, with the time part consumed only during
+// open hours, e.g. PT8H from Friday 15:00 is Monday 15:00 for 09:00 to
+// 17:00 hours. The date part is applied first, as by ShiftBusiness.
+//
+// Time is consumed from t onwards, so the result is never outside open
+// hours unless the time part is zero, and a result at closing time stays
+// there rather than moving to the next opening. Days are counted on the
+// wall clock in the location of w, whatever the location of t, and the
+// result is in the location of w; it is t unchanged if w has no open hours.
+func (w WorkingHours) Shift(t time.Time, d Duration) time.Time {
+	t = t.In(w.location(t))
+	t = Duration{Y: d.Y, M: d.M, W: d.W, D: d.D}.ShiftBusiness(t, w.Calendar)
+	return w.add(t, d.timeDuration())
+}
+
+// Unshift returns t shifted back by d, with the time part consumed only
+// during open hours, as for Shift.
+func (w WorkingHours) Unshift(t time.Time, d Duration) time.Time {
+	t = t.In(w.location(t))
+	t = Duration{Y: d.Y, M: d.M, W: d.W, D: d.D}.UnshiftBusiness(t, w.Calendar)
+	return w.add(t, -d.timeDuration())
+}
+
+// Between returns the open time from one time to another as a time-only
+// Duration, such that w.Shift(from, w.Between(from, to)) is to when to is
+// within open hours. The result is negative if to is before from.
+func (w WorkingHours) Between(from, to time.Time) Duration {
+	if to.Before(from) {
+		return w.Between(to, from).Negate()
+	}
+	loc := w.location(from)
+	span := Interval{Start: from, End: to}
+
+	var open time.Duration
+	for day := DateOf(from.In(loc)); !day.In(loc).After(to); day = day.Shift(Duration{D: 1}) {
+		if w.Calendar.IsBusinessDay(day) {
+			if hours := w.hoursOn(day, loc).Intersect(span); !hours.IsEmpty() {
+				open += hours.End.Sub(hours.Start)
+			}
+		}
+	}
+	return FromTimeDuration(open)
+}
+
+func (w WorkingHours) location(t time.Time) *time.Location {
+	if w.Location != nil {
+		return w.Location
+	}
+	return t.Location()
+}
+
+func (w WorkingHours) hoursOn(day Date, loc *time.Location) Interval {
+	return Interval{Start: w.Open.On(day, loc), End: w.Close.On(day, loc)}
+}
+
+// add moves t by r of open time, forwards or backwards, one day at a time.
+func (w WorkingHours) add(t time.Time, r time.Duration) time.Time {
+	loc := w.location(t)
+	t = t.In(loc)
+	if r == 0 || !w.Open.Before(w.Close) || w.Calendar.workdaysPerWeek() == 0 {
+		return t
+	}
+
+	if r > 0 {
+		for day := DateOf(t); ; day = day.Shift(Duration{D: 1}) {
+			if !w.Calendar.IsBusinessDay(day) {
+				continue
+			}
+			hours := w.hoursOn(day, loc)
+			start := laterOf(t, hours.Start)
+			if !start.Before(hours.End) {
+				continue
+			}
+			if open := hours.End.Sub(start); r > open {
+				r -= open
+				continue
+			}
+			return start.Add(r)
+		}
+	}
+
+	for day := DateOf(t); ; day = day.Unshift(Duration{D: 1}) {
+		if !w.Calendar.IsBusinessDay(day) {
+			continue
+		}
+		hours := w.hoursOn(day, loc)
+		end := earlierOf(t, hours.End)
+		if !end.After(hours.Start) {
+			continue
+		}
+		if open := end.Sub(hours.Start); -r > open {
+			r += open
+			continue
+		}
+		return end.Add(r)
+	}
+}
@@ -0,0 +1,134 @@
+package iso8601_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AbhijitDhariya/iso8601"
+)
+
+func officeHours(t *testing.T) (iso8601.WorkingHours, func(day, hour, minute int) time.Time) {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	hours := iso8601.WorkingHours{
+		Open:     iso8601.TimeOfDay{Hour: 9},
+		Close:    iso8601.TimeOfDay{Hour: 17},
+		Calendar: iso8601.EnglandWalesCalendar(),
+		Location: loc,
+	}
+	// March 2024: Friday 29th is Good Friday, Monday April 1st is Easter
+	// Monday, and British Summer Time starts on Sunday 31st.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, loc)
+	}
+	return hours, at
+}
+
+func TestWorkingHoursIsOpen(t *testing.T) {
+	hours, at := officeHours(t)
+	cases := []struct {
+		t    time.Time
+		open bool
+	}{
+		{at(26, 9, 0), true},
+		{at(26, 16, 59), true},
+		{at(26, 17, 0), false},
+		{at(26, 8, 59), false},
+		{at(29, 12, 0), false}, // Good Friday
+		{at(30, 12, 0), false}, // Saturday
+		{at(26, 12, 0).UTC(), true},
+	}
+	for _, c := range cases {
+		if got := hours.IsOpen(c.t); got != c.open {
+			t.Fatalf("%s: want=%t, got=%t", c.t, c.open, got)
+		}
+	}
+}
+
+func TestCanShiftByWorkingHours(t *testing.T) {
+	hours, at := officeHours(t)
+	cases := []struct {
+		from time.Time
+		d    iso8601.Duration
+		want time.Time
+	}{
+		{at(25, 9, 0), iso8601.Duration{TH: 8}, at(25, 17, 0)},
+		{at(25, 15, 0), iso8601.Duration{TH: 8}, at(26, 15, 0)},
+		{at(25, 20, 0), iso8601.Duration{TH: 1}, at(26, 10, 0)},
+		{at(25, 7, 0), iso8601.Duration{TM: 30}, at(25, 9, 30)},
+		{at(28, 16, 0), iso8601.Duration{TH: 2, TM: 30}, at(33, 10, 30)}, // over Easter to Tuesday, April 2nd
+		{at(26, 10, 0), iso8601.Duration{D: 1, TH: 8}, at(28, 10, 0)},
+		{at(30, 12, 0), iso8601.Duration{}, at(30, 12, 0)},
+		{at(26, 10, 0), iso8601.Duration{TH: -2}, at(25, 16, 0)},
+	}
+	for _, c := range cases {
+		got := hours.Shift(c.from, c.d)
+		if !got.Equal(c.want) {
+			t.Fatalf("%s + %s: want=%s, got=%s", c.from, c.d, c.want, got)
+		}
+	}
+
+	if got := hours.Unshift(at(33, 10, 30), iso8601.Duration{TH: 2, TM: 30}); !got.Equal(at(28, 16, 0)) {
+		t.Fatalf("want=%s, got=%s", at(28, 16, 0), got)
+	}
+}
+
+func TestWorkingHoursBetween(t *testing.T) {
+	hours, at := officeHours(t)
+	cases := []struct {
+		from, to time.Time
+		want     iso8601.Duration
+	}{
+		{at(25, 9, 0), at(25, 17, 0), iso8601.Duration{TH: 8}},
+		{at(25, 15, 0), at(26, 15, 0), iso8601.Duration{TH: 8}},
+		{at(25, 20, 0), at(26, 7, 0), iso8601.Duration{}},
+		{at(28, 16, 0), at(33, 10, 30), iso8601.Duration{TH: 2, TM: 30}},
+		{at(26, 15, 0), at(25, 15, 0), iso8601.Duration{TH: -8}},
+	}
+	for _, c := range cases {
+		got := hours.Between(c.from, c.to)
+		if !got.Equal(c.want) {
+			t.Fatalf("%s to %s: want=%s, got=%s", c.from, c.to, c.want, got)
+		}
+		if !c.want.IsZero() {
+			if shifted := hours.Shift(c.from, got); !shifted.Equal(c.to) {
+				t.Fatalf("%s + %s: want=%s, got=%s", c.from, got, c.to, shifted)
+			}
+		}
+	}
+}
+
+func TestWorkingHoursWithoutOpenHours(t *testing.T) {
+	closed := iso8601.WorkingHours{Open: iso8601.TimeOfDay{Hour: 9}, Close: iso8601.TimeOfDay{Hour: 9}}
+	from := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if got := closed.Shift(from, iso8601.Duration{TH: 1}); !got.Equal(from) {
+		t.Fatalf("want=%s, got=%s", from, got)
+	}
+}
+
+func TestWorkingHoursUseTheirOwnLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	hours := iso8601.WorkingHours{
+		Open:     iso8601.TimeOfDay{Hour: 9},
+		Close:    iso8601.TimeOfDay{Hour: 17},
+		Location: tokyo,
+	}
+	// Friday 20:00 UTC is already Saturday 05:00 in Tokyo.
+	utc := time.Date(2024, 5, 3, 20, 0, 0, 0, time.UTC)
+	for _, from := range []time.Time{utc, utc.In(tokyo)} {
+		got := hours.Shift(from, iso8601.Duration{D: 1})
+		if want := time.Date(2024, 5, 6, 5, 0, 0, 0, tokyo); !got.Equal(want) {
+			t.Fatalf("%s + P1D: want=%s, got=%s", from, want, got)
+		}
+		back := hours.Unshift(from, iso8601.Duration{D: 1})
+		if want := time.Date(2024, 5, 3, 5, 0, 0, 0, tokyo); !back.Equal(want) {
+			t.Fatalf("%s - P1D: want=%s, got=%s", from, want, back)
+		}
+	}
+}